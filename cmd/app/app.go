@@ -1,6 +1,7 @@
 package app
 
 import (
+	"github.com/vnworkday/account/internal/common/paging"
 	"github.com/vnworkday/account/internal/common/repo"
 	"github.com/vnworkday/account/internal/conf"
 	"github.com/vnworkday/account/internal/domain/repository"
@@ -16,6 +17,7 @@ func Run() {
 		conf.Register(),
 		logger.Register(),
 		repo.Register(),
+		paging.Register(),
 		repository.Register(),
 		usecase.Register(),
 		server.Register(),
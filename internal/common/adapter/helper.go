@@ -2,17 +2,44 @@ package adapter
 
 import (
 	"context"
+	"net/http"
 
 	"github.com/go-kit/kit/endpoint"
+	"github.com/go-kit/kit/transport"
 	"github.com/go-kit/kit/transport/grpc"
+	httptransport "github.com/go-kit/kit/transport/http"
 	"github.com/pkg/errors"
 	"github.com/vnworkday/account/internal/common/converter"
+	"github.com/vnworkday/account/internal/common/errs"
+	"go.uber.org/zap"
 )
 
+// LogInternalErrors logs the errors that clients only see as internal errors, with their cause, see isInternal.
+func LogInternalErrors(logger *zap.Logger) transport.ErrorHandler {
+	return transport.ErrorHandlerFunc(func(_ context.Context, err error) {
+		if isInternal(err) {
+			logger.Error("internal error", zap.Error(err))
+		}
+	})
+}
+
+// isInternal reports whether the error has no kind and no status code below 500, which tells that the request
+// failed on the side of the server.
+func isInternal(err error) bool {
+	if errs.As(err) != nil {
+		return false
+	}
+
+	var coder httptransport.StatusCoder
+
+	return !errors.As(err, &coder) || coder.StatusCode() >= http.StatusInternalServerError
+}
+
+// ServeGRPC serves the request with the handler and reports its errors as gRPC statuses, see ToGRPCStatus.
 func ServeGRPC[Req any, Resp any](ctx context.Context, request *Req, handler grpc.Handler) (*Resp, error) {
 	_, resp, err := handler.ServeGRPC(ctx, request)
 	if err != nil {
-		return nil, err
+		return nil, ToGRPCStatus(ctx, err).Err()
 	}
 
 	castResp, ok := resp.(*Resp)
@@ -27,6 +54,7 @@ func NewGRPCServer[Req, IReq, IResp, Resp any](
 	endpoint endpoint.Endpoint,
 	decodeRequest converter.ConvertFunc[Req, IReq],
 	encodeResponse converter.ConvertFunc[IResp, Resp],
+	options ...grpc.ServerOption,
 ) grpc.Handler {
 	return grpc.NewServer(
 		endpoint,
@@ -36,5 +64,6 @@ func NewGRPCServer[Req, IReq, IResp, Resp any](
 		func(ctx context.Context, out any) (any, error) {
 			return converter.Convert(ctx, out, encodeResponse)
 		},
+		options...,
 	)
 }
@@ -0,0 +1,93 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/i18n"
+)
+
+// internalMessage replaces the message of the errors without a kind, whose text may reveal queries or
+// other internals. Their cause is logged instead, see LogInternalErrors.
+const internalMessage = "internal error"
+
+// HTTPError carries the HTTP status code that should be returned for the wrapped error.
+type HTTPError struct {
+	code int
+	err  error
+}
+
+func NewHTTPError(code int, err error) *HTTPError {
+	return &HTTPError{code: code, err: err}
+}
+
+func (e *HTTPError) Error() string {
+	return e.err.Error()
+}
+
+func (e *HTTPError) Unwrap() error {
+	return e.err
+}
+
+func (e *HTTPError) StatusCode() int {
+	return e.code
+}
+
+type httpErrorBody struct {
+	Code             int              `json:"code"`
+	Message          string           `json:"message"`
+	LocalizedMessage string           `json:"localized_message"`
+	Reason           string           `json:"reason,omitempty"`
+	Violations       []errs.Violation `json:"violations,omitempty"`
+}
+
+func NewHTTPServer(
+	endpoint endpoint.Endpoint,
+	decodeRequest httptransport.DecodeRequestFunc,
+	encodeResponse httptransport.EncodeResponseFunc,
+	options ...httptransport.ServerOption,
+) *httptransport.Server {
+	options = append([]httptransport.ServerOption{
+		httptransport.ServerErrorEncoder(EncodeHTTPError),
+	}, options...)
+
+	return httptransport.NewServer(endpoint, decodeRequest, encodeResponse, options...)
+}
+
+// EncodeHTTPError writes the error as a JSON body, using the status code of the first error in the chain
+// that provides one, or else the status of the kind of the error, see errs.Kind. The localized message and the
+// descriptions of the violations are in the locale of the context, which the Content-Language header names.
+// Internal errors are sent with a generic message.
+func EncodeHTTPError(ctx context.Context, err error, writer http.ResponseWriter) {
+	locale := i18n.FromContext(ctx)
+	code := httpStatusCode(err)
+	body := httpErrorBody{Message: err.Error(), LocalizedMessage: unknownMessage(locale)}
+
+	if typed := errs.As(err); typed != nil {
+		body.Message = typed.Message
+		body.LocalizedMessage, body.Violations = localize(locale, typed)
+		body.Reason = typed.ErrorReason()
+	}
+
+	var coder httptransport.StatusCoder
+	if errors.As(err, &coder) {
+		code = coder.StatusCode()
+	}
+
+	if isInternal(err) {
+		body.Message = internalMessage
+	}
+
+	body.Code = code
+
+	writer.Header().Set("Content-Type", "application/json; charset=utf-8")
+	writer.Header().Set("Content-Language", string(locale))
+	writer.WriteHeader(code)
+
+	_ = json.NewEncoder(writer).Encode(body)
+}
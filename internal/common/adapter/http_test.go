@@ -0,0 +1,82 @@
+package adapter
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/fixture"
+	"github.com/vnworkday/account/internal/common/i18n"
+)
+
+func TestEncodeHTTPError(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Status   int
+		Language string
+		Body     map[string]any
+	}
+
+	tests := []struct {
+		name   string
+		locale i18n.Locale
+		input  error
+		want   result
+	}{
+		{
+			name:   "LocalizedViolations",
+			locale: i18n.Vietnamese,
+			input: NewHTTPError(http.StatusBadRequest, errs.NewAlreadyExists("tenant", "").
+				WithReason("TENANT_NAME_EXISTS").
+				WithViolations(errs.NewViolation("name", "ALREADY_TAKEN", nil))),
+			want: result{
+				Status:   http.StatusBadRequest,
+				Language: "vi",
+				Body: map[string]any{
+					"code":              float64(http.StatusBadRequest),
+					"message":           "tenant already exists",
+					"localized_message": "Đã có tổ chức sử dụng tên này",
+					"reason":            "TENANT_NAME_EXISTS",
+					"violations": []any{map[string]any{
+						"field":       "name",
+						"code":        "ALREADY_TAKEN",
+						"description": "đã được tổ chức khác sử dụng",
+					}},
+				},
+			},
+		},
+		{
+			name:   "Untyped",
+			locale: i18n.English,
+			input:  errors.New("database is down"),
+			want: result{
+				Status:   http.StatusInternalServerError,
+				Language: "en",
+				Body: map[string]any{
+					"code":              float64(http.StatusInternalServerError),
+					"message":           "internal error",
+					"localized_message": "An unexpected error occurred, please try again later",
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			recorder := httptest.NewRecorder()
+			EncodeHTTPError(i18n.WithLocale(context.Background(), tt.locale), tt.input, recorder)
+
+			got := result{Status: recorder.Code, Language: recorder.Header().Get("Content-Language")}
+			gotErr := json.Unmarshal(recorder.Body.Bytes(), &got.Body)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, false, gotErr)
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package adapter
+
+import (
+	"context"
+	"maps"
+	"net/http"
+
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/i18n"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/protoadapt"
+)
+
+var grpcCodes = map[errs.Kind]codes.Code{
+	errs.InvalidArgument:    codes.InvalidArgument,
+	errs.NotFound:           codes.NotFound,
+	errs.AlreadyExists:      codes.AlreadyExists,
+	errs.FailedPrecondition: codes.FailedPrecondition,
+	errs.PermissionDenied:   codes.PermissionDenied,
+	errs.Conflict:           codes.Aborted,
+}
+
+// httpCodes reports failed preconditions and conflicts with 409 Conflict. The handlers that read a version from the
+// If-Match header send 412 Precondition Failed themselves, with an HTTPError.
+var httpCodes = map[errs.Kind]int{
+	errs.InvalidArgument:    http.StatusBadRequest,
+	errs.NotFound:           http.StatusNotFound,
+	errs.AlreadyExists:      http.StatusConflict,
+	errs.FailedPrecondition: http.StatusConflict,
+	errs.PermissionDenied:   http.StatusForbidden,
+	errs.Conflict:           http.StatusConflict,
+}
+
+// ToGRPCStatus reports the error with the gRPC code of its kind, along with ErrorInfo details, a LocalizedMessage
+// in the locale of the context and, when they apply, BadRequest field violations and ResourceInfo. Errors without
+// a kind are INTERNAL, with a generic message, since their text may reveal queries or other internals. The message
+// of the status and the reason of the ErrorInfo stay in English.
+func ToGRPCStatus(ctx context.Context, err error) *status.Status {
+	locale := i18n.FromContext(ctx)
+
+	typed := errs.As(err)
+	if typed == nil {
+		return withDetails(status.New(codes.Internal, internalMessage), &errdetails.LocalizedMessage{
+			Locale:  string(locale),
+			Message: unknownMessage(locale),
+		})
+	}
+
+	message, violations := localize(locale, typed)
+
+	st := status.New(grpcCodes[typed.Kind], typed.Message)
+	details := []protoadapt.MessageV1{
+		&errdetails.ErrorInfo{
+			Reason:   typed.ErrorReason(),
+			Domain:   errs.Domain,
+			Metadata: typed.Metadata,
+		},
+		&errdetails.LocalizedMessage{
+			Locale:  string(locale),
+			Message: message,
+		},
+	}
+
+	if len(violations) > 0 {
+		fieldViolations := make([]*errdetails.BadRequest_FieldViolation, 0, len(violations))
+
+		for _, violation := range violations {
+			fieldViolations = append(fieldViolations, &errdetails.BadRequest_FieldViolation{
+				Field:       violation.Field,
+				Description: violation.Description,
+			})
+		}
+
+		details = append(details, &errdetails.BadRequest{FieldViolations: fieldViolations})
+	}
+
+	if typed.Resource != nil {
+		details = append(details, &errdetails.ResourceInfo{
+			ResourceType: typed.Resource.Type,
+			ResourceName: typed.Resource.Name,
+			Description:  typed.Message,
+		})
+	}
+
+	return withDetails(st, details...)
+}
+
+func withDetails(st *status.Status, details ...protoadapt.MessageV1) *status.Status {
+	if detailed, err := st.WithDetails(details...); err == nil {
+		return detailed
+	}
+
+	return st
+}
+
+// statusParams are the metadata of errors that hold the name of a status, which are translated before being
+// filled in messages.
+var statusParams = []string{"status", "from", "to"}
+
+// localize translates the message of the error, keyed by its reason or else by its kind, and the descriptions of
+// its violations that have a code. The message is filled in with the metadata of the error, whose statuses are
+// translated, and the name of its resource. Texts without a translation are kept as they are.
+func localize(locale i18n.Locale, typed *errs.Error) (string, []errs.Violation) {
+	params := maps.Clone(typed.Metadata)
+	if params == nil {
+		params = make(map[string]string)
+	}
+
+	for _, param := range statusParams {
+		if value, ok := params[param]; ok {
+			params[param] = i18n.Status(locale, value)
+		}
+	}
+
+	if typed.Resource != nil {
+		params["resource"] = i18n.Resource(locale, typed.Resource.Type)
+	}
+
+	message, ok := i18n.Translate(locale, typed.ErrorReason(), params)
+	if !ok {
+		if message, ok = i18n.Translate(locale, typed.Kind.String(), params); !ok {
+			message = typed.Message
+		}
+	}
+
+	violations := make([]errs.Violation, 0, len(typed.Violations))
+
+	for _, violation := range typed.Violations {
+		if description, found := i18n.Translate(locale, violation.Code, violation.Params); found {
+			violation.Description = description
+		}
+
+		violations = append(violations, violation)
+	}
+
+	return message, violations
+}
+
+func unknownMessage(locale i18n.Locale) string {
+	message, _ := i18n.Translate(locale, errs.Unknown.String(), nil)
+
+	return message
+}
+
+// httpStatusCode returns the HTTP status of the kind of the error, or 500 for errors without a kind.
+func httpStatusCode(err error) int {
+	if code, ok := httpCodes[errs.KindOf(err)]; ok {
+		return code
+	}
+
+	return http.StatusInternalServerError
+}
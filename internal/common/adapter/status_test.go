@@ -0,0 +1,174 @@
+package adapter
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/fixture"
+	"github.com/vnworkday/account/internal/common/i18n"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+)
+
+func TestToGRPCStatus(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Code    codes.Code
+		Message string
+		Details []string
+	}
+
+	tests := []struct {
+		name   string
+		locale i18n.Locale
+		input  error
+		want   result
+	}{
+		{
+			name:   "InvalidArgument",
+			locale: i18n.English,
+			input:  errs.NewInvalidArgument(errs.NewViolation("name", "MAX_LENGTH", map[string]string{"max": "255"})),
+			want: result{
+				Code:    codes.InvalidArgument,
+				Message: "invalid request",
+				Details: []string{
+					"INVALID_ARGUMENT",
+					"en: The request is invalid",
+					"name: must be at most 255 characters long",
+				},
+			},
+		},
+		{
+			name:   "LocalizedInvalidArgument",
+			locale: i18n.Vietnamese,
+			input: errs.NewInvalidArgument(
+				errs.NewViolation("name", "MAX_LENGTH", map[string]string{"max": "255"}),
+				errs.Violation{Field: "filter", Description: "unexpected token"},
+			),
+			want: result{
+				Code:    codes.InvalidArgument,
+				Message: "invalid request",
+				Details: []string{
+					"INVALID_ARGUMENT",
+					"vi: Yêu cầu không hợp lệ",
+					"name: chỉ được có tối đa 255 ký tự",
+					"filter: unexpected token",
+				},
+			},
+		},
+		{
+			name:   "WrappedNotFound",
+			locale: i18n.Vietnamese,
+			input:  errors.Wrap(errs.NewNotFound("tenant", "42"), "service"),
+			want: result{
+				Code:    codes.NotFound,
+				Message: "tenant not found",
+				Details: []string{"NOT_FOUND", "vi: Không tìm thấy tổ chức", "tenant/42"},
+			},
+		},
+		{
+			name:   "Conflict",
+			locale: i18n.English,
+			input: errs.New(errs.Conflict, "tenant has been modified").
+				WithReason("VERSION_MISMATCH").
+				WithResource("tenant", "42"),
+			want: result{
+				Code:    codes.Aborted,
+				Message: "tenant has been modified",
+				Details: []string{
+					"VERSION_MISMATCH",
+					"en: The tenant has been changed by someone else, please reload it",
+					"tenant/42",
+				},
+			},
+		},
+		{
+			name:   "LocalizedWithMetadata",
+			locale: i18n.Vietnamese,
+			input: errs.New(errs.FailedPrecondition, "tenant cannot move from deactivated to suspended").
+				WithReason("INVALID_STATUS_TRANSITION").
+				WithMetadata("from", "deactivated").
+				WithMetadata("to", "suspended"),
+			want: result{
+				Code:    codes.FailedPrecondition,
+				Message: "tenant cannot move from deactivated to suspended",
+				Details: []string{
+					"INVALID_STATUS_TRANSITION",
+					"vi: Không thể chuyển tổ chức từ trạng thái ngừng hoạt động sang tạm khóa",
+				},
+			},
+		},
+		{
+			name:   "Untyped",
+			locale: i18n.Vietnamese,
+			input:  errors.New("database is down"),
+			want: result{
+				Code:    codes.Internal,
+				Message: "internal error",
+				Details: []string{"vi: Đã xảy ra lỗi không mong muốn, vui lòng thử lại sau"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			st := ToGRPCStatus(i18n.WithLocale(context.Background(), tt.locale), tt.input)
+			got := result{Code: st.Code(), Message: st.Message(), Details: make([]string, 0)}
+
+			for _, detail := range st.Details() {
+				switch detail := detail.(type) {
+				case *errdetails.ErrorInfo:
+					got.Details = append(got.Details, detail.GetReason())
+				case *errdetails.LocalizedMessage:
+					got.Details = append(got.Details, detail.GetLocale()+": "+detail.GetMessage())
+				case *errdetails.BadRequest:
+					for _, violation := range detail.GetFieldViolations() {
+						got.Details = append(got.Details, violation.GetField()+": "+violation.GetDescription())
+					}
+				case *errdetails.ResourceInfo:
+					got.Details = append(got.Details, detail.GetResourceType()+"/"+detail.GetResourceName())
+				}
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, false, nil)
+		})
+	}
+}
+
+func TestHTTPStatusCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input error
+		want  int
+	}{
+		{name: "AlreadyExists", input: errs.NewAlreadyExists("tenant", ""), want: http.StatusConflict},
+		{
+			name:  "FailedPrecondition",
+			input: errs.New(errs.FailedPrecondition, "tenant is not deleted"),
+			want:  http.StatusConflict,
+		},
+		{
+			name:  "Conflict",
+			input: errs.New(errs.Conflict, "tenant has been modified"),
+			want:  http.StatusConflict,
+		},
+		{name: "PermissionDenied", input: errs.New(errs.PermissionDenied, "denied"), want: http.StatusForbidden},
+		{name: "Untyped", input: errors.New("database is down"), want: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fixture.ExpectationsWereMet(t, tt.want, httpStatusCode(tt.input), false, nil)
+		})
+	}
+}
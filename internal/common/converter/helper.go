@@ -6,13 +6,15 @@ import (
 	"github.com/pkg/errors"
 )
 
-type ConvertFunc[F, T any] func(*F) *T
+// ConvertFunc converts a transport message to a use case one or back. The context is the one of the call, which
+// carries its metadata.
+type ConvertFunc[F, T any] func(context.Context, *F) (*T, error)
 
-func Convert[F any, T any](_ context.Context, from any, converter func(from *F) *T) (any, error) {
+func Convert[F any, T any](ctx context.Context, from any, converter ConvertFunc[F, T]) (any, error) {
 	castFrom, ok := from.(*F)
 	if !ok {
 		return nil, errors.New("converter: cannot cast before converting")
 	}
 
-	return converter(castFrom), nil
+	return converter(ctx, castFrom)
 }
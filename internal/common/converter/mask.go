@@ -0,0 +1,38 @@
+package converter
+
+import (
+	"context"
+	"strings"
+
+	"github.com/vnworkday/account/internal/common/errs"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+)
+
+// UpdateMaskKey is the gRPC metadata key in which clients send the comma-separated paths of the fields that an
+// update changes, for update messages that have no google.protobuf.FieldMask field.
+const UpdateMaskKey = "update-mask"
+
+// IncomingFieldMask returns the update mask sent in the metadata of the incoming gRPC call, whose paths must be
+// fields of the message. It returns an empty mask when there is none.
+func IncomingFieldMask(ctx context.Context, message proto.Message) (*fieldmaskpb.FieldMask, error) {
+	paths := make([]string, 0)
+
+	for _, value := range metadata.ValueFromIncomingContext(ctx, UpdateMaskKey) {
+		for _, path := range strings.Split(value, ",") {
+			if path = strings.TrimSpace(path); path != "" {
+				paths = append(paths, path)
+			}
+		}
+	}
+
+	mask, err := fieldmaskpb.New(message, paths...)
+	if err != nil {
+		return nil, errs.NewInvalidArgument(errs.NewViolation(UpdateMaskKey, "UPDATE_MASK_FIELD", nil)).Wrap(err)
+	}
+
+	mask.Normalize()
+
+	return mask, nil
+}
@@ -0,0 +1,52 @@
+package converter
+
+import (
+	"context"
+	"testing"
+
+	tenantv1 "buf.build/gen/go/ntduycs/vnworkday/protocolbuffers/go/account/tenant/v1"
+	"github.com/vnworkday/account/internal/common/fixture"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestIncomingFieldMask(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		md      metadata.MD
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:    "WithPaths",
+			md:      metadata.Pairs(UpdateMaskKey, "self_registration_enabled, name"),
+			want:    []string{"name", "self_registration_enabled"},
+			wantErr: false,
+		},
+		{
+			name:    "WithoutMask",
+			md:      metadata.MD{},
+			want:    nil,
+			wantErr: false,
+		},
+		{
+			name:    "WithUnknownPath",
+			md:      metadata.Pairs(UpdateMaskKey, "domain"),
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := metadata.NewIncomingContext(context.Background(), tt.md)
+
+			got, gotErr := IncomingFieldMask(ctx, &tenantv1.UpdateTenantRequest{})
+
+			fixture.ExpectationsWereMet(t, tt.want, got.GetPaths(), tt.wantErr, gotErr)
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package converter
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// PublicIDKey is the gRPC response header in which the public ids of resources are sent. It is a stopgap until
+// the messages of the API, which are generated from another repository, get a field for them.
+const PublicIDKey = "public-id"
+
+// SendPublicID sets the public-id header of the gRPC call to the public ids, one value per resource in the order
+// of the resources of the response. It does nothing outside of a gRPC call, or without public ids.
+func SendPublicID(ctx context.Context, publicIDs ...string) {
+	if len(publicIDs) == 0 {
+		return
+	}
+
+	_ = grpc.SetHeader(ctx, metadata.MD{PublicIDKey: publicIDs})
+}
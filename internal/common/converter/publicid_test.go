@@ -0,0 +1,61 @@
+package converter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/fixture"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// headerStream collects the headers that a gRPC call sets.
+type headerStream struct {
+	grpc.ServerTransportStream
+
+	header metadata.MD
+}
+
+func (s *headerStream) SetHeader(md metadata.MD) error {
+	s.header = metadata.Join(s.header, md)
+
+	return nil
+}
+
+func TestSendPublicID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		publicIDs []string
+		want      metadata.MD
+	}{
+		{
+			name:      "SingleResource",
+			publicIDs: []string{"tn_7KQ2M9XD4HV0RB3C"},
+			want:      metadata.Pairs(PublicIDKey, "tn_7KQ2M9XD4HV0RB3C", ETagKey, `"3"`),
+		},
+		{
+			name:      "ResourcesInOrder",
+			publicIDs: []string{"tn_7KQ2M9XD4HV0RB3C", "tn_0P5W8N1T6YJ2G4EA"},
+			want: metadata.Pairs(
+				PublicIDKey, "tn_7KQ2M9XD4HV0RB3C", PublicIDKey, "tn_0P5W8N1T6YJ2G4EA", ETagKey, `"3"`,
+			),
+		},
+		{name: "WithoutResources", publicIDs: nil, want: metadata.Pairs(ETagKey, `"3"`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			stream := new(headerStream)
+			ctx := grpc.NewContextWithServerTransportStream(context.Background(), stream)
+
+			SendPublicID(ctx, tt.publicIDs...)
+			SendVersion(ctx, 3)
+
+			fixture.ExpectationsWereMet(t, tt.want, stream.header, false, nil)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package converter
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/vnworkday/account/internal/common/errs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// IfMatchKey is the HTTP header, and the gRPC metadata key, in which clients send the version of the
+	// resource they read before updating it.
+	IfMatchKey = "if-match"
+	// ETagKey is the HTTP header, and the gRPC response header, in which the version of a resource is sent.
+	ETagKey = "etag"
+	// ReasonVersionRequired is the reason of the error reported when an update that requires a version has none.
+	ReasonVersionRequired = "VERSION_REQUIRED"
+)
+
+// FormatETag renders the version as a strong entity tag, such as "3" with the quotes.
+func FormatETag(version int) string {
+	return strconv.Quote(strconv.Itoa(version))
+}
+
+// ParseETag reads a version from an entity tag, with or without its quotes. An empty tag is version 0, which
+// stands for no version.
+func ParseETag(tag string) (int, error) {
+	tag = strings.TrimSpace(tag)
+	if tag == "" {
+		return 0, nil
+	}
+
+	version, err := strconv.Atoi(strings.Trim(tag, `"`))
+	if err != nil || version <= 0 {
+		return 0, errs.NewInvalidArgument(errs.NewViolation(IfMatchKey, "ENTITY_TAG", map[string]string{"tag": tag}))
+	}
+
+	return version, nil
+}
+
+// IncomingVersion returns the version sent in the if-match metadata of the incoming gRPC call, or 0 when there
+// is none. The version is optional over gRPC, since the messages have no field for it.
+func IncomingVersion(ctx context.Context) (int, error) {
+	values := metadata.ValueFromIncomingContext(ctx, IfMatchKey)
+	if len(values) == 0 {
+		return 0, nil
+	}
+
+	return ParseETag(values[0])
+}
+
+// SendVersion sets the etag header of the gRPC call to the version. It does nothing outside of a gRPC call.
+func SendVersion(ctx context.Context, version int) {
+	_ = grpc.SetHeader(ctx, metadata.Pairs(ETagKey, FormatETag(version)))
+}
@@ -0,0 +1,61 @@
+package converter
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/fixture"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestParseETag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tag     string
+		want    int
+		wantErr bool
+	}{
+		{name: "QuotedTag", tag: FormatETag(3), want: 3, wantErr: false},
+		{name: "BareTag", tag: "3", want: 3, wantErr: false},
+		{name: "EmptyTag", tag: "", want: 0, wantErr: false},
+		{name: "WildcardTag", tag: "*", want: 0, wantErr: true},
+		{name: "NegativeTag", tag: `"-1"`, want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotErr := ParseETag(tt.tag)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestIncomingVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		md      metadata.MD
+		want    int
+		wantErr bool
+	}{
+		{name: "WithIfMatch", md: metadata.Pairs(IfMatchKey, `"7"`), want: 7, wantErr: false},
+		{name: "WithoutIfMatch", md: metadata.MD{}, want: 0, wantErr: false},
+		{name: "WithInvalidIfMatch", md: metadata.Pairs(IfMatchKey, "v7"), want: 0, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotErr := IncomingVersion(metadata.NewIncomingContext(context.Background(), tt.md))
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
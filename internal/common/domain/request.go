@@ -1,14 +1,40 @@
 package domain
 
+import (
+	"encoding/json"
+	"slices"
+
+	"github.com/pkg/errors"
+)
+
 type ListRequest struct {
 	Pagination Pagination
-	Filters    []Filter
+	Filters    []Condition
 	Sorts      []Sort
 }
 
 type Pagination struct {
-	Offset int `json:"offset"`
-	Limit  int `json:"limit"`
+	Offset int    `json:"offset"`
+	Limit  int    `json:"limit"`
+	Token  string `json:"token"`
+	// Cursor is the decoded Token: when set, the page starts right after (or before) the row it points at.
+	Cursor *Cursor `json:"-"`
+}
+
+// Cursor holds the sort-key values of the row that a keyset page starts from, in the order of the sorts of the
+// request. A backward cursor selects the rows that come before that row instead of after it.
+type Cursor struct {
+	Values   []any `json:"values"`
+	Backward bool  `json:"backward"`
+}
+
+// FieldMask lists the fields that an update changes, by their API names. An empty mask changes every field
+// that the update may change.
+type FieldMask []string
+
+// Has reports whether the update changes the field.
+func (m FieldMask) Has(field string) bool {
+	return len(m) == 0 || slices.Contains(m, field)
 }
 
 type SortOrder string
@@ -56,6 +82,7 @@ const (
 	Date
 	Time
 	DateTime
+	UUID
 )
 
 type Filter struct {
@@ -64,3 +91,94 @@ type Filter struct {
 	Op            Op     `json:"op"`
 	CaseSensitive bool   `json:"is_case_sensitive"`
 }
+
+// Condition is a predicate of a WHERE clause: either a single Filter or a FilterGroup of conditions.
+type Condition interface {
+	isCondition()
+}
+
+func (Filter) isCondition() {}
+
+type GroupOp int
+
+const (
+	_ GroupOp = iota
+	And
+	Or
+	Not
+)
+
+// FilterGroup combines its conditions with AND or OR. A NOT group negates the conjunction of its conditions.
+type FilterGroup struct {
+	Op         GroupOp     `json:"op"`
+	Conditions []Condition `json:"conditions"`
+}
+
+func (FilterGroup) isCondition() {}
+
+// conditionJSON wraps a condition of a group in JSON, where the key that is set tells a filter from a group,
+// such as {"filter": {"field": "name", ...}} or {"group": {"op": 2, "conditions": [...]}}.
+type conditionJSON struct {
+	Filter *Filter      `json:"filter,omitempty"`
+	Group  *FilterGroup `json:"group,omitempty"`
+}
+
+func (g FilterGroup) MarshalJSON() ([]byte, error) {
+	conditions := make([]conditionJSON, 0, len(g.Conditions))
+
+	for _, condition := range g.Conditions {
+		switch typed := condition.(type) {
+		case Filter:
+			conditions = append(conditions, conditionJSON{Filter: &typed})
+		case FilterGroup:
+			conditions = append(conditions, conditionJSON{Group: &typed})
+		default:
+			return nil, errors.Errorf("domain: unsupported condition %T", condition)
+		}
+	}
+
+	return json.Marshal(struct {
+		Op         GroupOp         `json:"op"`
+		Conditions []conditionJSON `json:"conditions"`
+	}{Op: g.Op, Conditions: conditions})
+}
+
+func (g *FilterGroup) UnmarshalJSON(data []byte) error {
+	var decoded struct {
+		Op         GroupOp         `json:"op"`
+		Conditions []conditionJSON `json:"conditions"`
+	}
+
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+
+	conditions := make([]Condition, 0, len(decoded.Conditions))
+
+	for idx, condition := range decoded.Conditions {
+		switch {
+		case condition.Filter != nil && condition.Group == nil:
+			conditions = append(conditions, *condition.Filter)
+		case condition.Group != nil && condition.Filter == nil:
+			conditions = append(conditions, *condition.Group)
+		default:
+			return errors.Errorf("domain: condition %d must be either a filter or a group", idx)
+		}
+	}
+
+	g.Op, g.Conditions = decoded.Op, conditions
+
+	return nil
+}
+
+func AllOf(conditions ...Condition) FilterGroup {
+	return FilterGroup{Op: And, Conditions: conditions}
+}
+
+func AnyOf(conditions ...Condition) FilterGroup {
+	return FilterGroup{Op: Or, Conditions: conditions}
+}
+
+func Negate(condition Condition) FilterGroup {
+	return FilterGroup{Op: Not, Conditions: []Condition{condition}}
+}
@@ -0,0 +1,87 @@
+package domain
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+func TestFieldMask_Has(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		mask  FieldMask
+		field string
+		want  bool
+	}{
+		{name: "EmptyMaskHasEveryField", mask: FieldMask{}, field: "name", want: true},
+		{name: "MaskedField", mask: FieldMask{"name"}, field: "name", want: true},
+		{name: "UnmaskedField", mask: FieldMask{"name"}, field: "status", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fixture.ExpectationsWereMet(t, tt.want, tt.mask.Has(tt.field), false, nil)
+		})
+	}
+}
+
+func TestFilterGroup_JSON(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		group   FilterGroup
+		data    string
+		wantErr bool
+	}{
+		{
+			name: "WithFilters",
+			group: AllOf(
+				Filter{Field: "name", Op: Eq, Value: "acme"},
+				Filter{Field: "status", Op: Ne, Value: float64(2)},
+			),
+			data: `{"op":1,"conditions":[` +
+				`{"filter":{"field":"name","value":"acme","op":1,"is_case_sensitive":false}},` +
+				`{"filter":{"field":"status","value":2,"op":2,"is_case_sensitive":false}}]}`,
+		},
+		{
+			name: "WithNestedGroup",
+			group: AnyOf(
+				Filter{Field: "name", Op: Eq, Value: "acme"},
+				Negate(Filter{Field: "domain", Op: Null}),
+			),
+			data: `{"op":2,"conditions":[` +
+				`{"filter":{"field":"name","value":"acme","op":1,"is_case_sensitive":false}},` +
+				`{"group":{"op":3,"conditions":[` +
+				`{"filter":{"field":"domain","value":null,"op":13,"is_case_sensitive":false}}]}}]}`,
+		},
+		{
+			name:    "WithUntypedCondition",
+			data:    `{"op":1,"conditions":[{"field":"name","value":"acme","op":1}]}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got FilterGroup
+
+			err := json.Unmarshal([]byte(tt.data), &got)
+			if err == nil {
+				var data []byte
+
+				data, err = json.Marshal(got)
+				fixture.ExpectationsWereMet(t, tt.data, string(data), false, err)
+			}
+
+			fixture.ExpectationsWereMet(t, tt.group, got, tt.wantErr, err)
+		})
+	}
+}
@@ -1,13 +1,16 @@
 package domain
 
 type Page struct {
-	Next       int `json:"next"`
-	Prev       int `json:"prev"`
-	Total      int `json:"total"`
-	TotalPages int `json:"total_pages"`
+	Next       int    `json:"next"`
+	Prev       int    `json:"prev"`
+	NextToken  string `json:"next_token,omitempty"`
+	PrevToken  string `json:"prev_token,omitempty"`
+	Total      int    `json:"total"`
+	TotalPages int    `json:"total_pages"`
 }
 
 type ListResponse[T any] struct {
 	Items []*T `json:"items"`
 	Count int  `json:"count"`
+	Page  Page `json:"page"`
 }
@@ -11,20 +11,48 @@ type Table struct {
 	Name       string
 	Columns    []string
 	Insertable []string
-	Updatable  []string
+	// Updatable lists the columns that an update writes when it names none. It leaves out the softdelete column,
+	// which only the updates that name it change, so that a row is never deleted or restored by accident.
+	Updatable []string
+	// PrimaryKey lists the columns tagged pk, in field order.
+	PrimaryKey []string
+	// Version is the column tagged version, which is bumped on every update to detect concurrent writes.
+	Version string
+	// SoftDelete is the column tagged softdelete, which is set instead of deleting the row. Rows where it is not
+	// NULL are hidden from queries.
+	SoftDelete string
 }
 
+// Column is a db tag parsed by ParseTag. The tag is the column name followed by comma-separated options:
+//
+//   - generated: the database fills the column, so it is never inserted.
+//   - immutable: the column is never updated.
+//   - pk: the column is part of the primary key, which is immutable.
+//   - version: the optimistic lock column, which is bumped instead of being updated.
+//   - softdelete: the deletion mark of the row.
+//   - json, jsonb: the field is stored as a JSON document.
+//   - readonly: the column is only read, never inserted nor updated.
+//   - omitempty: a zero field inserts the DEFAULT of the column.
+//   - default=expr: a zero field inserts the SQL expression expr.
+//
+// A tag of "-" skips the field.
 type Column struct {
-	Name      string
-	Generated bool
-	Immutable bool
+	Name       string
+	Generated  bool
+	Immutable  bool
+	PrimaryKey bool
+	Version    bool
+	SoftDelete bool
+	JSON       bool
+	ReadOnly   bool
+	OmitEmpty  bool
+	Default    string
 }
 
 func StructToTable(target any, tableName string) (*Table, error) {
 	var structValue reflect.Value
 
 	value := reflect.ValueOf(target)
-	table := Table{Name: tableName}
 	columns := make([]Column, 0)
 
 	if !isStructOrStructPointer(value) {
@@ -45,7 +73,11 @@ func StructToTable(target any, tableName string) (*Table, error) {
 			return nil, errors.Errorf("repository: missing db tag on field %s", field.Name)
 		}
 
-		column, err := parseTag(tag, field.Name)
+		if tag == "-" {
+			continue
+		}
+
+		column, err := ParseTag(tag, field.Name)
 		if err != nil {
 			return nil, err
 		}
@@ -53,45 +85,126 @@ func StructToTable(target any, tableName string) (*Table, error) {
 		columns = append(columns, column)
 	}
 
-	table = columnsToTable(columns)
+	table, err := columnsToTable(columns)
+	if err != nil {
+		return nil, err
+	}
+
+	table.Name = tableName
 
 	return &table, nil
 }
 
-func parseTag(tag string, field string) (Column, error) {
+// StructValues returns the values of the fields of target whose db tags name the given columns, in the same
+// order as the columns.
+func StructValues(target any, columns ...string) ([]any, error) {
+	value := reflect.ValueOf(target)
+
+	if !isStructOrStructPointer(value) {
+		return nil, errors.New("repository: target must be a struct or a struct pointer")
+	}
+
+	value = reflect.Indirect(value)
+	indexes := make(map[string]int, value.NumField())
+
+	for i := range value.NumField() {
+		name, _, _ := strings.Cut(value.Type().Field(i).Tag.Get("db"), ",")
+		if name != "" && name != "-" {
+			indexes[name] = i
+		}
+	}
+
+	values := make([]any, 0, len(columns))
+
+	for _, column := range columns {
+		index, ok := indexes[column]
+		if !ok {
+			return nil, errors.Errorf("repository: no field is tagged with column %s", column)
+		}
+
+		values = append(values, value.Field(index).Interface())
+	}
+
+	return values, nil
+}
+
+// ParseTag parses the db tag of the given field.
+func ParseTag(tag string, field string) (Column, error) {
 	parts := strings.Split(tag, ",")
 	column := Column{Name: parts[0]}
 
+	if column.Name == "" || column.Name == "-" {
+		return Column{}, errors.Errorf("repository: missing column name in tag of field %s", field)
+	}
+
 	for _, part := range parts[1:] {
 		switch part {
 		case "generated":
 			column.Generated = true
 		case "immutable":
 			column.Immutable = true
+		case "pk":
+			column.PrimaryKey = true
+		case "version":
+			column.Version = true
+		case "softdelete":
+			column.SoftDelete = true
+		case "json", "jsonb":
+			column.JSON = true
+		case "readonly":
+			column.ReadOnly = true
+		case "omitempty":
+			column.OmitEmpty = true
 		default:
-			return Column{}, errors.Errorf("repository: invalid tag option %s for field %s", part, field)
+			expr, ok := strings.CutPrefix(part, "default=")
+			if !ok || expr == "" {
+				return Column{}, errors.Errorf("repository: invalid tag option %s for field %s", part, field)
+			}
+
+			column.Default = expr
 		}
 	}
 
 	return column, nil
 }
 
-func columnsToTable(columns []Column) Table {
+func columnsToTable(columns []Column) (Table, error) {
 	var table Table
 
 	for _, column := range columns {
 		table.Columns = append(table.Columns, column.Name)
 
-		if !column.Generated {
+		if !column.Generated && !column.ReadOnly {
 			table.Insertable = append(table.Insertable, column.Name)
 		}
 
-		if !column.Immutable {
+		if !column.Immutable && !column.PrimaryKey && !column.Version && !column.ReadOnly && !column.SoftDelete {
 			table.Updatable = append(table.Updatable, column.Name)
 		}
+
+		if column.PrimaryKey {
+			table.PrimaryKey = append(table.PrimaryKey, column.Name)
+		}
+
+		if column.Version {
+			if table.Version != "" {
+				return Table{}, errors.Errorf("repository: both %s and %s are tagged version", table.Version, column.Name)
+			}
+
+			table.Version = column.Name
+		}
+
+		if column.SoftDelete {
+			if table.SoftDelete != "" {
+				return Table{}, errors.Errorf("repository: both %s and %s are tagged softdelete",
+					table.SoftDelete, column.Name)
+			}
+
+			table.SoftDelete = column.Name
+		}
 	}
 
-	return table
+	return table, nil
 }
 
 func isStructOrStructPointer(value reflect.Value) bool {
@@ -22,6 +22,7 @@ func TestStructToTable(t *testing.T) {
 				FirstName string `db:"firstname"`
 			}{},
 			want: &Table{
+				Name:       "table",
 				Columns:    []string{"pid", "firstname"},
 				Insertable: []string{"pid", "firstname"},
 				Updatable:  []string{"pid", "firstname"},
@@ -35,6 +36,7 @@ func TestStructToTable(t *testing.T) {
 				FirstName string `db:"firstname"`
 			}{},
 			want: &Table{
+				Name:       "table",
 				Columns:    []string{"pid", "firstname"},
 				Insertable: []string{"pid", "firstname"},
 				Updatable:  []string{"pid", "firstname"},
@@ -49,12 +51,44 @@ func TestStructToTable(t *testing.T) {
 				LastName  string `db:"lastname,generated,immutable"`
 			}{},
 			want: &Table{
+				Name:       "table",
 				Columns:    []string{"pid", "firstname", "lastname"},
 				Insertable: []string{"firstname"},
 				Updatable:  []string{"pid"},
 			},
 			wantErr: false,
 		},
+		{
+			name: "ValidStructWithRichTagsOption",
+			input: struct {
+				ID        int            `db:"id,pk"`
+				Name      string         `db:"name"`
+				Settings  map[string]any `db:"settings,jsonb"`
+				Version   int            `db:"version,version,default=1"`
+				Slug      string         `db:"slug,readonly"`
+				DeletedAt *string        `db:"deleted_at,softdelete"`
+				Cache     string         `db:"-"`
+			}{},
+			want: &Table{
+				Name:       "table",
+				Columns:    []string{"id", "name", "settings", "version", "slug", "deleted_at"},
+				Insertable: []string{"id", "name", "settings", "version", "deleted_at"},
+				Updatable:  []string{"name", "settings"},
+				PrimaryKey: []string{"id"},
+				Version:    "version",
+				SoftDelete: "deleted_at",
+			},
+			wantErr: false,
+		},
+		{
+			name: "StructWithTwoVersionColumns",
+			input: struct {
+				Version  int `db:"version,version"`
+				Revision int `db:"revision,version"`
+			}{},
+			want:    nil,
+			wantErr: true,
+		},
 		{
 			name: "StructPointerWithoutTags",
 			input: &struct {
@@ -97,3 +131,117 @@ func TestStructToTable(t *testing.T) {
 		})
 	}
 }
+
+func TestParseTag(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		tag     string
+		want    Column
+		wantErr bool
+	}{
+		{
+			name:    "NameOnly",
+			tag:     "name",
+			want:    Column{Name: "name"},
+			wantErr: false,
+		},
+		{
+			name:    "JSONAndOmitEmpty",
+			tag:     "settings,json,omitempty",
+			want:    Column{Name: "settings", JSON: true, OmitEmpty: true},
+			wantErr: false,
+		},
+		{
+			name:    "DefaultExpression",
+			tag:     "created_at,immutable,default=now()",
+			want:    Column{Name: "created_at", Immutable: true, Default: "now()"},
+			wantErr: false,
+		},
+		{
+			name:    "EmptyDefault",
+			tag:     "created_at,default=",
+			want:    Column{},
+			wantErr: true,
+		},
+		{
+			name:    "UnknownOption",
+			tag:     "name,unique",
+			want:    Column{},
+			wantErr: true,
+		},
+		{
+			name:    "MissingName",
+			tag:     ",pk",
+			want:    Column{},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotErr := ParseTag(tt.tag, "Field")
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestStructValues(t *testing.T) {
+	t.Parallel()
+
+	type row struct {
+		ID        int    `db:"pid,generated"`
+		FirstName string `db:"firstname"`
+	}
+
+	tests := []struct {
+		name    string
+		input   any
+		columns []string
+		want    []any
+		wantErr bool
+	}{
+		{
+			name:    "ValuesInColumnOrder",
+			input:   row{ID: 7, FirstName: "An"},
+			columns: []string{"firstname", "pid"},
+			want:    []any{"An", 7},
+			wantErr: false,
+		},
+		{
+			name:    "StructPointer",
+			input:   &row{ID: 7, FirstName: "An"},
+			columns: []string{"pid"},
+			want:    []any{7},
+			wantErr: false,
+		},
+		{
+			name:    "UnknownColumn",
+			input:   row{},
+			columns: []string{"lastname"},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "NeitherStructOrStructPointer",
+			input:   123,
+			columns: []string{"pid"},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotErr := StructValues(tt.input, tt.columns...)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
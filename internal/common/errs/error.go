@@ -0,0 +1,210 @@
+// Package errs defines the kinds of errors that clients can act upon, whichever layer detects them, so that the
+// transports can report them with the matching gRPC or HTTP status.
+package errs
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/i18n"
+)
+
+// Domain names the service in the ErrorInfo details of the errors it reports.
+const Domain = "account.vnworkday.com"
+
+type Kind int
+
+const (
+	// Unknown is the kind of every error that is not an *Error: a failure of the service itself.
+	Unknown Kind = iota
+	InvalidArgument
+	NotFound
+	AlreadyExists
+	FailedPrecondition
+	PermissionDenied
+	// Conflict reports a write that raced with another one, which the client may retry after reading again.
+	Conflict
+)
+
+var kindReasons = map[Kind]string{
+	Unknown:            "UNKNOWN",
+	InvalidArgument:    "INVALID_ARGUMENT",
+	NotFound:           "NOT_FOUND",
+	AlreadyExists:      "ALREADY_EXISTS",
+	FailedPrecondition: "FAILED_PRECONDITION",
+	PermissionDenied:   "PERMISSION_DENIED",
+	Conflict:           "CONFLICT",
+}
+
+func (k Kind) String() string {
+	return kindReasons[k]
+}
+
+// Violation describes a field of a request that is invalid. Its code, when it has one, identifies the
+// description in the message catalogs, so that it can be translated along with its parameters.
+type Violation struct {
+	Field       string            `json:"field"`
+	Code        string            `json:"code,omitempty"`
+	Description string            `json:"description"`
+	Params      map[string]string `json:"-"`
+}
+
+// NewViolation describes the field with the English message of the code.
+func NewViolation(field string, code string, params map[string]string) Violation {
+	description, _ := i18n.Translate(i18n.English, code, params)
+
+	return Violation{Field: field, Code: code, Description: description, Params: params}
+}
+
+// Resource identifies the resource that an error is about.
+type Resource struct {
+	Type string `json:"type"`
+	Name string `json:"name"`
+}
+
+// Error is an error of a Kind. Its message is meant for clients, while its cause, if any, is only logged.
+type Error struct {
+	Kind    Kind
+	Message string
+	// Reason is the UPPER_SNAKE_CASE identifier of the error, which defaults to the name of its kind.
+	Reason     string
+	Resource   *Resource
+	Violations []Violation
+	Metadata   map[string]string
+	cause      error
+}
+
+func New(kind Kind, format string, args ...any) *Error {
+	return &Error{Kind: kind, Message: fmt.Sprintf(format, args...)}
+}
+
+// NewInvalidArgument reports invalid fields of a request.
+func NewInvalidArgument(violations ...Violation) *Error {
+	err := New(InvalidArgument, "invalid request")
+	err.Violations = violations
+
+	return err
+}
+
+// NewNotFound reports a missing resource, whose name may be empty when it was looked up by other fields.
+func NewNotFound(resourceType string, name string) *Error {
+	err := New(NotFound, "%s not found", resourceType)
+	err.Resource = &Resource{Type: resourceType, Name: name}
+
+	return err
+}
+
+// NewAlreadyExists reports a resource that cannot be created because another one has the same identity.
+func NewAlreadyExists(resourceType string, name string) *Error {
+	err := New(AlreadyExists, "%s already exists", resourceType)
+	err.Resource = &Resource{Type: resourceType, Name: name}
+
+	return err
+}
+
+// Error returns the message followed by the violations and the cause, if any.
+func (e *Error) Error() string {
+	var builder strings.Builder
+
+	builder.WriteString(e.Message)
+
+	for idx, violation := range e.Violations {
+		if idx == 0 {
+			builder.WriteString(": ")
+		} else {
+			builder.WriteString("; ")
+		}
+
+		builder.WriteString(violation.Field + " " + violation.Description)
+	}
+
+	if e.cause != nil {
+		builder.WriteString(": " + e.cause.Error())
+	}
+
+	return builder.String()
+}
+
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+// Wrap records the cause of the error.
+func (e *Error) Wrap(cause error) *Error {
+	e.cause = cause
+
+	return e
+}
+
+// WithReason sets the reason of the error.
+func (e *Error) WithReason(reason string) *Error {
+	e.Reason = reason
+
+	return e
+}
+
+// WithResource sets the resource that the error is about.
+func (e *Error) WithResource(resourceType string, name string) *Error {
+	e.Resource = &Resource{Type: resourceType, Name: name}
+
+	return e
+}
+
+// WithViolations adds invalid fields of the request to the error.
+func (e *Error) WithViolations(violations ...Violation) *Error {
+	e.Violations = append(e.Violations, violations...)
+
+	return e
+}
+
+// WithMetadata adds a key and value to the ErrorInfo details of the error.
+func (e *Error) WithMetadata(key string, value string) *Error {
+	if e.Metadata == nil {
+		e.Metadata = make(map[string]string)
+	}
+
+	e.Metadata[key] = value
+
+	return e
+}
+
+// ErrorReason returns the reason of the error, or the name of its kind.
+func (e *Error) ErrorReason() string {
+	if e.Reason != "" {
+		return e.Reason
+	}
+
+	return e.Kind.String()
+}
+
+// kinded is implemented by errors of other packages, such as parser errors, that always belong to a kind.
+type kinded interface {
+	error
+	ErrorKind() Kind
+}
+
+// As returns the *Error in the chain of err, converting the first error that belongs to a kind otherwise. It
+// returns nil when no error in the chain has a kind.
+func As(err error) *Error {
+	var typed *Error
+	if errors.As(err, &typed) {
+		return typed
+	}
+
+	var other kinded
+	if errors.As(err, &other) {
+		return New(other.ErrorKind(), "%s", other.Error())
+	}
+
+	return nil
+}
+
+// KindOf returns the kind of the error, which is Unknown for errors without a kind.
+func KindOf(err error) Kind {
+	if typed := As(err); typed != nil {
+		return typed.Kind
+	}
+
+	return Unknown
+}
@@ -0,0 +1,108 @@
+package errs
+
+import (
+	"database/sql"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+type kindedError struct{}
+
+func (kindedError) Error() string {
+	return "parser: unexpected token"
+}
+
+func (kindedError) ErrorKind() Kind {
+	return InvalidArgument
+}
+
+func TestError_Error(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input *Error
+		want  string
+	}{
+		{
+			name:  "Message",
+			input: NewNotFound("tenant", "42"),
+			want:  "tenant not found",
+		},
+		{
+			name:  "MessageAndCause",
+			input: NewNotFound("tenant", "42").Wrap(sql.ErrNoRows),
+			want:  "tenant not found: sql: no rows in result set",
+		},
+		{
+			name: "Violations",
+			input: NewInvalidArgument(
+				Violation{Field: "name", Description: "is required"},
+				Violation{Field: "limit", Description: "must be at most 100"},
+			),
+			want: "invalid request: name is required; limit must be at most 100",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fixture.ExpectationsWereMet(t, tt.want, tt.input.Error(), false, nil)
+		})
+	}
+}
+
+func TestKindOf(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input error
+		want  Kind
+	}{
+		{name: "Typed", input: New(Conflict, "tenant has been modified"), want: Conflict},
+		{name: "WrappedTyped", input: errors.Wrap(NewAlreadyExists("tenant", ""), "service"), want: AlreadyExists},
+		{name: "Kinded", input: errors.Wrap(kindedError{}, "converter"), want: InvalidArgument},
+		{name: "Untyped", input: errors.New("database is down"), want: Unknown},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fixture.ExpectationsWereMet(t, tt.want, KindOf(tt.input), false, nil)
+		})
+	}
+}
+
+func TestError_ErrorReason(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input *Error
+		want  string
+	}{
+		{name: "DefaultReason", input: New(FailedPrecondition, "version is required"), want: "FAILED_PRECONDITION"},
+		{name: "CustomReason", input: NewAlreadyExists("tenant", "").WithReason("TENANT_NAME_EXISTS"), want: "TENANT_NAME_EXISTS"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fixture.ExpectationsWereMet(t, tt.want, tt.input.ErrorReason(), false, nil)
+		})
+	}
+}
+
+func TestError_Unwrap(t *testing.T) {
+	t.Parallel()
+
+	err := errors.Wrap(NewNotFound("tenant", "42").Wrap(sql.ErrNoRows), "service")
+
+	fixture.ExpectationsWereMet(t, true, errors.Is(err, sql.ErrNoRows), false, nil)
+}
@@ -0,0 +1,144 @@
+package i18n
+
+import (
+	"strings"
+)
+
+// Catalogs are keyed by the stable codes that clients act upon: the reasons of errors, the codes of the field
+// violations, RESOURCE_ followed by the type of a resource and STATUS_ followed by the name of a status. A message
+// refers to its parameters by {name}.
+var catalogs = map[Locale]map[string]string{
+	English: {
+		"UNKNOWN":              "An unexpected error occurred, please try again later",
+		"INVALID_ARGUMENT":     "The request is invalid",
+		"NOT_FOUND":            "The {resource} was not found",
+		"ALREADY_EXISTS":       "The {resource} already exists",
+		"FAILED_PRECONDITION":  "The request cannot be performed in the current state",
+		"PERMISSION_DENIED":    "You are not allowed to perform this request",
+		"CONFLICT":             "The request conflicts with another change, please try again",
+		"VERSION_REQUIRED":     "The version of the {resource} that the change is based on is required",
+		"VERSION_MISMATCH":     "The {resource} has been changed by someone else, please reload it",
+		"INVALID_PAGE_TOKEN":   "The page token is invalid or has expired",
+		"TENANT_NAME_EXISTS":   "A tenant with this name already exists",
+		"TENANT_DOMAIN_EXISTS": "A tenant with this domain already exists",
+		"TENANT_NOT_ACTIVE":    "The tenant is {status} and cannot be used",
+
+		"INVALID_STATUS_TRANSITION":     "The tenant cannot move from {from} to {to}",
+		"TENANT_NOT_DELETED":            "The tenant is not deleted",
+		"DELETION_GRACE_PERIOD_EXPIRED": "The tenant was deleted too long ago to be restored",
+
+		"REQUIRED":               "is required",
+		"MIN_LENGTH":             "must be at least {min} characters long",
+		"MAX_LENGTH":             "must be at most {max} characters long",
+		"MIN_ITEMS":              "must have at least {min} items",
+		"MAX_ITEMS":              "must have at most {max} items",
+		"MIN_VALUE":              "must be at least {min}",
+		"MAX_VALUE":              "must be at most {max}",
+		"PATTERN":                "must match the pattern {pattern}",
+		"ENUM":                   "must be one of {values}",
+		"TIMEZONE":               "must be an IANA time zone",
+		"HOSTNAME":               "must be a hostname",
+		"UUID":                   "must be a UUID",
+		"ALREADY_TAKEN":          "is already taken by another tenant",
+		"PAGE_TOKEN":             "is not a token issued for this request",
+		"OFFSET_WITH_PAGE_TOKEN": "cannot be combined with a page token",
+		"ENTITY_TAG":             "must be the entity tag of a version, got {tag}",
+		"UPDATE_MASK_FIELD":      "must only name fields that can be updated",
+		"FILTER_OPERATOR":        "has an unsupported operator {operator}",
+
+		"RESOURCE_TENANT": "tenant",
+
+		"STATUS_PENDING":          "pending",
+		"STATUS_ACTIVE":           "active",
+		"STATUS_DEACTIVATED":      "deactivated",
+		"STATUS_SUSPENDED":        "suspended",
+		"STATUS_PENDING_DELETION": "pending deletion",
+	},
+	Vietnamese: {
+		"UNKNOWN":              "Đã xảy ra lỗi không mong muốn, vui lòng thử lại sau",
+		"INVALID_ARGUMENT":     "Yêu cầu không hợp lệ",
+		"NOT_FOUND":            "Không tìm thấy {resource}",
+		"ALREADY_EXISTS":       "Dữ liệu {resource} đã tồn tại",
+		"FAILED_PRECONDITION":  "Không thể thực hiện yêu cầu ở trạng thái hiện tại",
+		"PERMISSION_DENIED":    "Bạn không có quyền thực hiện yêu cầu này",
+		"CONFLICT":             "Yêu cầu xung đột với một thay đổi khác, vui lòng thử lại",
+		"VERSION_REQUIRED":     "Cần có phiên bản của {resource} mà thay đổi dựa trên",
+		"VERSION_MISMATCH":     "Dữ liệu {resource} đã được người khác thay đổi, vui lòng tải lại",
+		"INVALID_PAGE_TOKEN":   "Mã trang không hợp lệ hoặc đã hết hạn",
+		"TENANT_NAME_EXISTS":   "Đã có tổ chức sử dụng tên này",
+		"TENANT_DOMAIN_EXISTS": "Đã có tổ chức sử dụng tên miền này",
+		"TENANT_NOT_ACTIVE":    "Tổ chức đang ở trạng thái {status} và không thể sử dụng",
+
+		"INVALID_STATUS_TRANSITION":     "Không thể chuyển tổ chức từ trạng thái {from} sang {to}",
+		"TENANT_NOT_DELETED":            "Tổ chức chưa bị xóa",
+		"DELETION_GRACE_PERIOD_EXPIRED": "Tổ chức đã bị xóa quá lâu nên không thể khôi phục",
+
+		"REQUIRED":               "là bắt buộc",
+		"MIN_LENGTH":             "phải có ít nhất {min} ký tự",
+		"MAX_LENGTH":             "chỉ được có tối đa {max} ký tự",
+		"MIN_ITEMS":              "phải có ít nhất {min} phần tử",
+		"MAX_ITEMS":              "chỉ được có tối đa {max} phần tử",
+		"MIN_VALUE":              "phải lớn hơn hoặc bằng {min}",
+		"MAX_VALUE":              "phải nhỏ hơn hoặc bằng {max}",
+		"PATTERN":                "phải khớp với mẫu {pattern}",
+		"ENUM":                   "phải là một trong các giá trị {values}",
+		"TIMEZONE":               "phải là một múi giờ IANA",
+		"HOSTNAME":               "phải là một tên miền hợp lệ",
+		"UUID":                   "phải là một UUID",
+		"ALREADY_TAKEN":          "đã được tổ chức khác sử dụng",
+		"PAGE_TOKEN":             "không phải là mã trang được cấp cho yêu cầu này",
+		"OFFSET_WITH_PAGE_TOKEN": "không thể dùng cùng với mã trang",
+		"ENTITY_TAG":             "phải là entity tag của một phiên bản, nhận được {tag}",
+		"UPDATE_MASK_FIELD":      "chỉ được chứa các trường có thể cập nhật",
+		"FILTER_OPERATOR":        "có toán tử không được hỗ trợ {operator}",
+
+		"RESOURCE_TENANT": "tổ chức",
+
+		"STATUS_PENDING":          "chờ kích hoạt",
+		"STATUS_ACTIVE":           "hoạt động",
+		"STATUS_DEACTIVATED":      "ngừng hoạt động",
+		"STATUS_SUSPENDED":        "tạm khóa",
+		"STATUS_PENDING_DELETION": "chờ xóa",
+	},
+}
+
+// Translate returns the message of the code in the locale, or in English when the locale has none, with its
+// parameters filled in. It reports false when no catalog knows the code.
+func Translate(locale Locale, code string, params map[string]string) (string, bool) {
+	message, ok := catalogs[locale][code]
+	if !ok {
+		if message, ok = catalogs[English][code]; !ok {
+			return "", false
+		}
+	}
+
+	if len(params) == 0 {
+		return message, true
+	}
+
+	pairs := make([]string, 0, 2*len(params))
+
+	for name, value := range params {
+		pairs = append(pairs, "{"+name+"}", value)
+	}
+
+	return strings.NewReplacer(pairs...).Replace(message), true
+}
+
+// Resource returns the name of a type of resource in the locale, or the type itself when it has no translation.
+func Resource(locale Locale, resourceType string) string {
+	if name, ok := Translate(locale, "RESOURCE_"+strings.ToUpper(resourceType), nil); ok {
+		return name
+	}
+
+	return resourceType
+}
+
+// Status returns the name of a status in the locale, or the name itself when it has no translation.
+func Status(locale Locale, status string) string {
+	if name, ok := Translate(locale, "STATUS_"+strings.ToUpper(status), nil); ok {
+		return name
+	}
+
+	return status
+}
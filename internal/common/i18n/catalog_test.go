@@ -0,0 +1,90 @@
+package i18n
+
+import (
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+func TestTranslate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name   string
+		locale Locale
+		code   string
+		params map[string]string
+		want   string
+		found  bool
+	}{
+		{
+			name:   "English",
+			locale: English,
+			code:   "MAX_LENGTH",
+			params: map[string]string{"max": "255"},
+			want:   "must be at most 255 characters long",
+			found:  true,
+		},
+		{
+			name:   "Vietnamese",
+			locale: Vietnamese,
+			code:   "NOT_FOUND",
+			params: map[string]string{"resource": Resource(Vietnamese, "tenant")},
+			want:   "Không tìm thấy tổ chức",
+			found:  true,
+		},
+		{
+			name:   "VietnameseStatus",
+			locale: Vietnamese,
+			code:   "TENANT_NOT_ACTIVE",
+			params: map[string]string{"status": Status(Vietnamese, "pending_deletion")},
+			want:   "Tổ chức đang ở trạng thái chờ xóa và không thể sử dụng",
+			found:  true,
+		},
+		{
+			name:   "UnsupportedLocale",
+			locale: Locale("fr"),
+			code:   "REQUIRED",
+			want:   "is required",
+			found:  true,
+		},
+		{
+			name:   "UnknownCode",
+			locale: Vietnamese,
+			code:   "UNEXPECTED_TOKEN",
+			want:   "",
+			found:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, found := Translate(tt.locale, tt.code, tt.params)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, false, nil)
+			fixture.ExpectationsWereMet(t, tt.found, found, false, nil)
+		})
+	}
+}
+
+// TestCatalogs_Complete keeps the catalogs in step, since a code missing from one of them would be sent in
+// English to its clients.
+func TestCatalogs_Complete(t *testing.T) {
+	t.Parallel()
+
+	for locale, catalog := range catalogs {
+		for code := range catalogs[English] {
+			if _, ok := catalog[code]; !ok {
+				t.Errorf("catalog %s has no message for %s", locale, code)
+			}
+		}
+
+		for code := range catalog {
+			if _, ok := catalogs[English][code]; !ok {
+				t.Errorf("catalog %s has a message for %s, which the English catalog lacks", locale, code)
+			}
+		}
+	}
+}
@@ -0,0 +1,91 @@
+// Package i18n translates the messages that the service sends to clients into the locale they ask for.
+//
+// Clients that ask for no supported locale get the DefaultLocale setting, which is global. Tenants have no locale
+// of their own: the tenant messages of the API have no field for one, and a request does not say which tenant its
+// caller belongs to. Falling back to the locale of the caller's tenant is left out until both are available.
+package i18n
+
+import (
+	"context"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// AcceptLanguageKey is the HTTP header, and the gRPC metadata key, in which clients list the locales they
+// prefer, such as "vi-VN, en;q=0.8".
+const AcceptLanguageKey = "accept-language"
+
+type Locale string
+
+const (
+	English    Locale = "en"
+	Vietnamese Locale = "vi"
+)
+
+// Parse returns the supported locale of a language tag, ignoring its region, so that "vi-VN" is Vietnamese.
+func Parse(tag string) (Locale, bool) {
+	language, _, _ := strings.Cut(strings.TrimSpace(tag), "-")
+
+	locale := Locale(strings.ToLower(language))
+	if _, ok := catalogs[locale]; !ok {
+		return "", false
+	}
+
+	return locale, true
+}
+
+// Negotiate returns the supported locale that the Accept-Language value prefers, or the fallback when it names
+// none of them.
+func Negotiate(acceptLanguage string, fallback Locale) Locale {
+	type candidate struct {
+		locale  Locale
+		quality float64
+	}
+
+	candidates := make([]candidate, 0)
+
+	for _, entry := range strings.Split(acceptLanguage, ",") {
+		tag, weight, _ := strings.Cut(entry, ";")
+		quality := 1.0
+
+		if value, ok := strings.CutPrefix(strings.TrimSpace(weight), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+
+			quality = parsed
+		}
+
+		if locale, ok := Parse(tag); ok && quality > 0 {
+			candidates = append(candidates, candidate{locale: locale, quality: quality})
+		}
+	}
+
+	if len(candidates) == 0 {
+		return fallback
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+
+	return candidates[0].locale
+}
+
+type localeKey struct{}
+
+// WithLocale returns a context in which messages are translated into the locale.
+func WithLocale(ctx context.Context, locale Locale) context.Context {
+	return context.WithValue(ctx, localeKey{}, locale)
+}
+
+// FromContext returns the locale of the context, which is English when none was set.
+func FromContext(ctx context.Context) Locale {
+	if locale, ok := ctx.Value(localeKey{}).(Locale); ok {
+		return locale
+	}
+
+	return English
+}
@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+func TestNegotiate(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name           string
+		acceptLanguage string
+		want           Locale
+	}{
+		{name: "Empty", acceptLanguage: "", want: Vietnamese},
+		{name: "SingleLanguage", acceptLanguage: "en", want: English},
+		{name: "LanguageWithRegion", acceptLanguage: "en-US", want: English},
+		{name: "HighestQuality", acceptLanguage: "en;q=0.5, vi-VN;q=0.9", want: Vietnamese},
+		{name: "FirstOfEqualQuality", acceptLanguage: "en-GB, vi", want: English},
+		{name: "SkipUnsupported", acceptLanguage: "fr-FR, en;q=0.1", want: English},
+		{name: "SkipRejected", acceptLanguage: "en;q=0", want: Vietnamese},
+		{name: "OnlyUnsupported", acceptLanguage: "fr, de;q=0.8", want: Vietnamese},
+		{name: "Wildcard", acceptLanguage: "*", want: Vietnamese},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fixture.ExpectationsWereMet(t, tt.want, Negotiate(tt.acceptLanguage, Vietnamese), false, nil)
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input context.Context
+		want  Locale
+	}{
+		{name: "WithLocale", input: WithLocale(context.Background(), Vietnamese), want: Vietnamese},
+		{name: "WithoutLocale", input: context.Background(), want: English},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fixture.ExpectationsWereMet(t, tt.want, FromContext(tt.input), false, nil)
+		})
+	}
+}
@@ -0,0 +1,13 @@
+package paging
+
+import (
+	"github.com/vnworkday/common/pkg/ioc"
+	"go.uber.org/fx"
+)
+
+func Register() fx.Option {
+	return fx.Provide(
+		ioc.RegisterWithName(NewCodec),
+		ioc.RegisterWithName(NewPolicy),
+	)
+}
@@ -0,0 +1,99 @@
+package paging
+
+import (
+	"slices"
+
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/errs"
+)
+
+// Prepare decodes the page token of the request into its cursor and returns the request to run against the
+// repository, which fetches one extra row to tell whether another page follows.
+func (c *Codec) Prepare(request *domain.ListRequest) (*domain.ListRequest, error) {
+	prepared := *request
+
+	if request.Pagination.Token != "" {
+		if request.Pagination.Offset > 0 {
+			return nil, errs.NewInvalidArgument(errs.NewViolation("offset", "OFFSET_WITH_PAGE_TOKEN", nil))
+		}
+
+		fingerprint, err := Fingerprint(request)
+		if err != nil {
+			return nil, err
+		}
+
+		prepared.Pagination.Cursor, err = c.Decode(request.Pagination.Token, fingerprint)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if prepared.Pagination.Limit > 0 {
+		prepared.Pagination.Limit++
+	}
+
+	return &prepared, nil
+}
+
+// Keyset turns the rows fetched for a prepared request into the requested page: it drops the extra row, puts
+// the rows of a backward page back in the requested order and issues the tokens of the neighbouring pages,
+// keyed on the sort columns of the first and last rows.
+func Keyset[T any](codec *Codec, prepared *domain.ListRequest, items []*T) ([]*T, domain.Page, error) {
+	var page domain.Page
+
+	limit := prepared.Pagination.Limit - 1
+	if limit <= 0 {
+		return items, page, nil
+	}
+
+	cursor := prepared.Pagination.Cursor
+	backward := cursor != nil && cursor.Backward
+	more := len(items) > limit
+
+	if more {
+		items = items[:limit]
+	}
+
+	if backward {
+		items = slices.Clone(items)
+		slices.Reverse(items)
+	}
+
+	if len(items) == 0 {
+		return items, page, nil
+	}
+
+	fingerprint, err := Fingerprint(prepared)
+	if err != nil {
+		return nil, page, err
+	}
+
+	if more || backward {
+		if page.NextToken, err = codec.token(items[len(items)-1], prepared.Sorts, false, fingerprint); err != nil {
+			return nil, page, err
+		}
+	}
+
+	if (more && backward) || (cursor != nil && !backward) {
+		if page.PrevToken, err = codec.token(items[0], prepared.Sorts, true, fingerprint); err != nil {
+			return nil, page, err
+		}
+	}
+
+	return items, page, nil
+}
+
+func (c *Codec) token(item any, sorts []domain.Sort, backward bool, fingerprint string) (string, error) {
+	columns := make([]string, 0, len(sorts))
+
+	for _, sort := range sorts {
+		columns = append(columns, sort.Field)
+	}
+
+	values, err := domain.StructValues(item, columns...)
+	if err != nil {
+		return "", err
+	}
+
+	return c.Encode(domain.Cursor{Values: values, Backward: backward}, fingerprint)
+}
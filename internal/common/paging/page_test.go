@@ -0,0 +1,181 @@
+package paging
+
+import (
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+type testRow struct {
+	ID int `db:"id"`
+}
+
+func rows(ids ...int) []*testRow {
+	out := make([]*testRow, 0, len(ids))
+
+	for _, id := range ids {
+		out = append(out, &testRow{ID: id})
+	}
+
+	return out
+}
+
+func TestKeyset(t *testing.T) {
+	t.Parallel()
+
+	codec := newTestCodec(t, "secret")
+	sorts := []domain.Sort{{Field: "id", Order: domain.Asc}}
+
+	type result struct {
+		IDs     []int
+		HasNext bool
+		HasPrev bool
+	}
+
+	tests := []struct {
+		name   string
+		cursor *domain.Cursor
+		limit  int
+		items  []*testRow
+		want   result
+	}{
+		{
+			name:  "FirstPageWithMore",
+			limit: 2,
+			items: rows(1, 2, 3),
+			want:  result{IDs: []int{1, 2}, HasNext: true},
+		},
+		{
+			name:  "OnlyPage",
+			limit: 2,
+			items: rows(1, 2),
+			want:  result{IDs: []int{1, 2}},
+		},
+		{
+			name:   "MiddlePageForward",
+			cursor: &domain.Cursor{Values: []any{2}},
+			limit:  2,
+			items:  rows(3, 4, 5),
+			want:   result{IDs: []int{3, 4}, HasNext: true, HasPrev: true},
+		},
+		{
+			name:   "LastPageForward",
+			cursor: &domain.Cursor{Values: []any{4}},
+			limit:  2,
+			items:  rows(5),
+			want:   result{IDs: []int{5}, HasPrev: true},
+		},
+		{
+			name:   "MiddlePageBackward",
+			cursor: &domain.Cursor{Values: []any{5}, Backward: true},
+			limit:  2,
+			items:  rows(4, 3, 2),
+			want:   result{IDs: []int{3, 4}, HasNext: true, HasPrev: true},
+		},
+		{
+			name:   "FirstPageBackward",
+			cursor: &domain.Cursor{Values: []any{3}, Backward: true},
+			limit:  2,
+			items:  rows(2, 1),
+			want:   result{IDs: []int{1, 2}, HasNext: true},
+		},
+		{
+			name:  "Unlimited",
+			limit: 0,
+			items: rows(1, 2, 3),
+			want:  result{IDs: []int{1, 2, 3}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			prepared := &domain.ListRequest{
+				Pagination: domain.Pagination{Limit: tt.limit, Cursor: tt.cursor},
+				Sorts:      sorts,
+			}
+
+			if tt.limit > 0 {
+				prepared.Pagination.Limit++
+			}
+
+			items, page, gotErr := Keyset(codec, prepared, tt.items)
+
+			got := result{HasNext: page.NextToken != "", HasPrev: page.PrevToken != ""}
+			for _, item := range items {
+				got.IDs = append(got.IDs, item.ID)
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, false, gotErr)
+		})
+	}
+}
+
+func TestCodec_Prepare(t *testing.T) {
+	t.Parallel()
+
+	codec := newTestCodec(t, "secret")
+	request := &domain.ListRequest{Sorts: []domain.Sort{{Field: "id", Order: domain.Asc}}}
+
+	fingerprint, err := Fingerprint(request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	token, err := codec.Encode(domain.Cursor{Values: []any{"abc"}}, fingerprint)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		pagination domain.Pagination
+		want       domain.Pagination
+		wantErr    bool
+	}{
+		{
+			name:       "FetchesOneExtraRow",
+			pagination: domain.Pagination{Offset: 4, Limit: 10},
+			want:       domain.Pagination{Offset: 4, Limit: 11},
+		},
+		{
+			name:       "DecodesToken",
+			pagination: domain.Pagination{Limit: 10, Token: token},
+			want: domain.Pagination{
+				Limit:  11,
+				Token:  token,
+				Cursor: &domain.Cursor{Values: []any{"abc"}},
+			},
+		},
+		{
+			name:       "TokenWithOffset",
+			pagination: domain.Pagination{Offset: 4, Limit: 10, Token: token},
+			wantErr:    true,
+		},
+		{
+			name:       "InvalidToken",
+			pagination: domain.Pagination{Limit: 10, Token: "abc.def"},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			input := *request
+			input.Pagination = tt.pagination
+
+			prepared, gotErr := codec.Prepare(&input)
+
+			var got domain.Pagination
+			if prepared != nil {
+				got = prepared.Pagination
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package paging
+
+import (
+	"strconv"
+
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/conf"
+	"go.uber.org/fx"
+)
+
+// Policy bounds the pages that clients may request.
+type Policy struct {
+	pageSize    int
+	maxPageSize int
+}
+
+type PolicyParams struct {
+	fx.In
+	Config *conf.Conf
+}
+
+func NewPolicy(params PolicyParams) *Policy {
+	return &Policy{
+		pageSize:    params.Config.ListPageSize,
+		maxPageSize: params.Config.ListMaxPageSize,
+	}
+}
+
+// Apply returns the pagination with the default page size when the limit is missing, and rejects negative
+// offsets and limits as well as limits above the maximum page size.
+func (p *Policy) Apply(pagination domain.Pagination) (domain.Pagination, error) {
+	if pagination.Offset < 0 {
+		return pagination, invalid("offset", "MIN_VALUE", "min", 0)
+	}
+
+	switch {
+	case pagination.Limit < 0:
+		return pagination, invalid("limit", "MIN_VALUE", "min", 0)
+	case pagination.Limit > p.maxPageSize:
+		return pagination, invalid("limit", "MAX_VALUE", "max", p.maxPageSize)
+	case pagination.Limit == 0:
+		pagination.Limit = p.pageSize
+	}
+
+	return pagination, nil
+}
+
+func invalid(field string, code string, param string, bound int) error {
+	return errs.NewInvalidArgument(errs.NewViolation(field, code, map[string]string{param: strconv.Itoa(bound)}))
+}
+
+// TotalPages is the number of pages of the given size needed to list total items.
+func TotalPages(total, limit int) int {
+	if limit <= 0 || total <= 0 {
+		return 0
+	}
+
+	return (total + limit - 1) / limit
+}
@@ -0,0 +1,82 @@
+package paging
+
+import (
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/fixture"
+	"github.com/vnworkday/account/internal/conf"
+)
+
+func TestPolicy_Apply(t *testing.T) {
+	t.Parallel()
+
+	policy := NewPolicy(PolicyParams{Config: &conf.Conf{ListPageSize: 20, ListMaxPageSize: 100}})
+
+	tests := []struct {
+		name    string
+		input   domain.Pagination
+		want    domain.Pagination
+		wantErr bool
+	}{
+		{
+			name:  "DefaultPageSize",
+			input: domain.Pagination{Offset: 40},
+			want:  domain.Pagination{Offset: 40, Limit: 20},
+		},
+		{
+			name:  "MaxPageSize",
+			input: domain.Pagination{Limit: 100, Token: "token"},
+			want:  domain.Pagination{Limit: 100, Token: "token"},
+		},
+		{
+			name:    "AboveMaxPageSize",
+			input:   domain.Pagination{Limit: 101},
+			wantErr: true,
+		},
+		{
+			name:    "NegativeLimit",
+			input:   domain.Pagination{Limit: -1},
+			wantErr: true,
+		},
+		{
+			name:    "NegativeOffset",
+			input:   domain.Pagination{Offset: -20, Limit: 20},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotErr := policy.Apply(tt.input)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestTotalPages(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		total int
+		limit int
+		want  int
+	}{
+		{name: "Empty", total: 0, limit: 20, want: 0},
+		{name: "PartialLastPage", total: 41, limit: 20, want: 3},
+		{name: "FullLastPage", total: 40, limit: 20, want: 2},
+		{name: "Unlimited", total: 40, limit: 0, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fixture.ExpectationsWereMet(t, tt.want, TotalPages(tt.total, tt.limit), false, nil)
+		})
+	}
+}
@@ -0,0 +1,142 @@
+package paging
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/conf"
+	"go.uber.org/fx"
+)
+
+const (
+	keySize         = 32
+	fingerprintSize = 16
+	tokenSeparator  = "."
+)
+
+// ErrInvalidToken is the cause of the errors returned for page tokens that are malformed, were not issued by
+// this service, or were issued for a different filter or sort. Match it with errors.Is.
+var ErrInvalidToken = errors.New("paging: invalid page token")
+
+// invalidToken returns a fresh InvalidArgument error wrapping ErrInvalidToken, which callers can add to.
+func invalidToken() error {
+	return errs.NewInvalidArgument(errs.NewViolation("page_token", "PAGE_TOKEN", nil)).
+		WithReason("INVALID_PAGE_TOKEN").
+		Wrap(ErrInvalidToken)
+}
+
+// Codec issues and verifies opaque page tokens. A token is the base64 encoded cursor followed by its
+// HMAC-SHA256, so clients can neither read nor forge it.
+type Codec struct {
+	key []byte
+}
+
+type CodecParams struct {
+	fx.In
+	Config *conf.Conf
+}
+
+// NewCodec signs tokens with the configured secret. Without one a random key is generated, so tokens do not
+// survive a restart and are not shared between replicas.
+func NewCodec(params CodecParams) (*Codec, error) {
+	key := []byte(params.Config.PageTokenSecret)
+
+	if len(key) == 0 {
+		key = make([]byte, keySize)
+
+		if _, err := rand.Read(key); err != nil {
+			return nil, errors.Wrap(err, "paging: cannot generate token key")
+		}
+	}
+
+	return &Codec{key: key}, nil
+}
+
+type payload struct {
+	Values      []any  `json:"values"`
+	Backward    bool   `json:"backward"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Encode issues a token for the cursor, bound to the fingerprint of the request it paginates.
+func (c *Codec) Encode(cursor domain.Cursor, fingerprint string) (string, error) {
+	data, err := json.Marshal(payload{
+		Values:      cursor.Values,
+		Backward:    cursor.Backward,
+		Fingerprint: fingerprint,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "paging: cannot encode cursor")
+	}
+
+	return base64.RawURLEncoding.EncodeToString(data) + tokenSeparator +
+		base64.RawURLEncoding.EncodeToString(c.sign(data)), nil
+}
+
+// Decode verifies the token and returns its cursor. Numbers are decoded as json.Number so that large integer
+// keys keep their precision.
+func (c *Codec) Decode(token string, fingerprint string) (*domain.Cursor, error) {
+	encoded, signature, ok := strings.Cut(token, tokenSeparator)
+	if !ok {
+		return nil, invalidToken()
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, invalidToken()
+	}
+
+	mac, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(mac, c.sign(data)) {
+		return nil, invalidToken()
+	}
+
+	var decoded payload
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	if err = decoder.Decode(&decoded); err != nil {
+		return nil, invalidToken()
+	}
+
+	if decoded.Fingerprint != fingerprint {
+		return nil, errors.Wrap(invalidToken(), "paging: filter or sort changed since the token was issued")
+	}
+
+	return &domain.Cursor{Values: decoded.Values, Backward: decoded.Backward}, nil
+}
+
+func (c *Codec) sign(data []byte) []byte {
+	mac := hmac.New(sha256.New, c.key)
+	mac.Write(data)
+
+	return mac.Sum(nil)
+}
+
+// Fingerprint digests the filters and sorts of a request. A token only continues a listing with the same
+// fingerprint, since its cursor is meaningless for a different result set.
+func Fingerprint(request *domain.ListRequest) (string, error) {
+	data, err := json.Marshal(struct {
+		Filters []domain.Condition `json:"filters"`
+		Sorts   []domain.Sort      `json:"sorts"`
+	}{
+		Filters: request.Filters,
+		Sorts:   request.Sorts,
+	})
+	if err != nil {
+		return "", errors.Wrap(err, "paging: cannot fingerprint request")
+	}
+
+	digest := sha256.Sum256(data)
+
+	return base64.RawURLEncoding.EncodeToString(digest[:fingerprintSize]), nil
+}
@@ -0,0 +1,182 @@
+package paging
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/fixture"
+	"github.com/vnworkday/account/internal/conf"
+)
+
+func newTestCodec(t *testing.T, secret string) *Codec {
+	t.Helper()
+
+	codec, err := NewCodec(CodecParams{Config: &conf.Conf{PageTokenSecret: secret}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return codec
+}
+
+func TestCodec_Decode(t *testing.T) {
+	t.Parallel()
+
+	codec := newTestCodec(t, "secret")
+	cursor := domain.Cursor{Values: []any{"2024-01-01T00:00:00Z", 42}, Backward: true}
+
+	token, err := codec.Encode(cursor, "fingerprint")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	encoded, signature, _ := strings.Cut(token, tokenSeparator)
+	tampered := []byte(encoded)
+	tampered[len(tampered)/2] ^= 1
+
+	tests := []struct {
+		name        string
+		codec       *Codec
+		token       string
+		fingerprint string
+		want        *domain.Cursor
+		wantErr     bool
+	}{
+		{
+			name:        "RoundTrip",
+			codec:       codec,
+			token:       token,
+			fingerprint: "fingerprint",
+			want: &domain.Cursor{
+				Values:   []any{"2024-01-01T00:00:00Z", json.Number("42")},
+				Backward: true,
+			},
+		},
+		{
+			name:        "DifferentFingerprint",
+			codec:       codec,
+			token:       token,
+			fingerprint: "other",
+			wantErr:     true,
+		},
+		{
+			name:        "TamperedPayload",
+			codec:       codec,
+			token:       string(tampered) + tokenSeparator + signature,
+			fingerprint: "fingerprint",
+			wantErr:     true,
+		},
+		{
+			name:        "DifferentSecret",
+			codec:       newTestCodec(t, "another secret"),
+			token:       token,
+			fingerprint: "fingerprint",
+			wantErr:     true,
+		},
+		{
+			name:        "GeneratedSecret",
+			codec:       newTestCodec(t, ""),
+			token:       token,
+			fingerprint: "fingerprint",
+			wantErr:     true,
+		},
+		{
+			name:        "Malformed",
+			codec:       codec,
+			token:       "not a token",
+			fingerprint: "fingerprint",
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotErr := tt.codec.Decode(tt.token, tt.fingerprint)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestCodec_DecodeError(t *testing.T) {
+	t.Parallel()
+
+	codec := newTestCodec(t, "secret")
+
+	_, first := codec.Decode("not a token", "fingerprint")
+	_, second := codec.Decode("not a token", "fingerprint")
+
+	errs.As(first).WithMetadata("request", "first")
+
+	type result struct {
+		Is       bool
+		Kind     errs.Kind
+		Metadata map[string]string
+	}
+
+	got := result{Is: errors.Is(second, ErrInvalidToken), Kind: errs.KindOf(second), Metadata: errs.As(second).Metadata}
+
+	fixture.ExpectationsWereMet(t, result{Is: true, Kind: errs.InvalidArgument}, got, false, nil)
+}
+
+func TestFingerprint(t *testing.T) {
+	t.Parallel()
+
+	base := &domain.ListRequest{
+		Filters: []domain.Condition{domain.Filter{Field: "status", Op: domain.Eq, Value: 1}},
+		Sorts:   []domain.Sort{{Field: "id", Order: domain.Asc}},
+	}
+
+	tests := []struct {
+		name    string
+		request *domain.ListRequest
+		want    bool
+	}{
+		{
+			name: "SameFiltersAndSortsOnAnotherPage",
+			request: &domain.ListRequest{
+				Pagination: domain.Pagination{Limit: 5, Token: "token"},
+				Filters:    base.Filters,
+				Sorts:      base.Sorts,
+			},
+			want: true,
+		},
+		{
+			name: "DifferentFilterValue",
+			request: &domain.ListRequest{
+				Filters: []domain.Condition{domain.Filter{Field: "status", Op: domain.Eq, Value: 2}},
+				Sorts:   base.Sorts,
+			},
+			want: false,
+		},
+		{
+			name: "DifferentSortOrder",
+			request: &domain.ListRequest{
+				Filters: base.Filters,
+				Sorts:   []domain.Sort{{Field: "id", Order: domain.Desc}},
+			},
+			want: false,
+		},
+	}
+
+	want, err := Fingerprint(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotErr := Fingerprint(tt.request)
+
+			fixture.ExpectationsWereMet(t, tt.want, got == want, false, gotErr)
+		})
+	}
+}
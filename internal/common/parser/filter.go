@@ -0,0 +1,408 @@
+package parser
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/repo"
+)
+
+const (
+	maxDepth = 32
+
+	nullValue = "null"
+	wildcard  = "*"
+	dateLen   = len("2006-01-02")
+)
+
+// Field describes a field that can be filtered on.
+type Field struct {
+	Column string
+	Type   domain.FilterValueType
+}
+
+// Schema whitelists the fields of an entity that can be filtered on, keyed by their public name.
+type Schema map[string]Field
+
+// Fields returns the public names of the fields, in alphabetical order.
+func (s Schema) Fields() []string {
+	fields := make([]string, 0, len(s))
+
+	for name := range s {
+		fields = append(fields, name)
+	}
+
+	sort.Strings(fields)
+
+	return fields
+}
+
+// SortColumns replaces the public names of the sorted fields by their columns.
+func (s Schema) SortColumns(sorts []domain.Sort) []domain.Sort {
+	columns := make([]domain.Sort, 0, len(sorts))
+
+	for _, sort := range sorts {
+		if field, ok := s[sort.Field]; ok {
+			sort.Field = field.Column
+		}
+
+		columns = append(columns, sort)
+	}
+
+	return columns
+}
+
+// Error reports an invalid filter along with the column, counted in characters from 1, where it was detected.
+// The column is 0 when the filter was not parsed from an expression.
+type Error struct {
+	Column  int
+	Message string
+}
+
+func newError(column int, format string, args ...any) *Error {
+	return &Error{Column: column, Message: fmt.Sprintf(format, args...)}
+}
+
+// ErrorKind reports parser errors as invalid arguments, since they always come from the input of a client.
+func (e *Error) ErrorKind() errs.Kind {
+	return errs.InvalidArgument
+}
+
+func (e *Error) Error() string {
+	if e.Column == 0 {
+		return "parser: " + e.Message
+	}
+
+	return fmt.Sprintf("parser: %s at column %d", e.Message, e.Column)
+}
+
+// ParseFilter parses an AIP-160 style filter expression into a condition over the columns of the schema.
+//
+// Restrictions compare a field with a value using =, !=, <, <=, >, >= or : (has, which means contains for
+// strings and equals otherwise). Values are bare words or quoted strings; the bare word null matches missing
+// values, and a leading or trailing * in a string compared with = matches a prefix, a suffix or a substring.
+// Restrictions combine with AND, OR, NOT, a leading - and parentheses. As in AIP-160, OR binds tighter than
+// AND, and juxtaposed restrictions are implicitly combined with AND.
+//
+// Example:
+//
+//	status = 1 AND name:"Công ty" AND created_at > "2024-01-01"
+//
+// An empty expression yields a nil condition.
+func ParseFilter(input string, schema Schema) (domain.Condition, error) {
+	if strings.TrimSpace(input) == "" {
+		return nil, nil
+	}
+
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &filterParser{tokens: tokens, schema: schema}
+
+	condition, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+
+	if next := p.peek(); next.kind != tokenEOF {
+		return nil, newError(next.column, "unexpected %s", next)
+	}
+
+	return condition, nil
+}
+
+// Filter builds a filter on the named field, casting the raw value to the type of the field. The values of
+// list operators are separated by commas.
+func (s Schema) Filter(name string, op domain.Op, raw string, caseSensitive bool) (domain.Filter, error) {
+	return s.FilterList(name, op, raw, ",", caseSensitive)
+}
+
+// FilterList is Filter for lists separated by the given separator.
+func (s Schema) FilterList(
+	name string,
+	op domain.Op,
+	raw string,
+	separator string,
+	caseSensitive bool,
+) (domain.Filter, error) {
+	field, ok := s[name]
+	if !ok {
+		return domain.Filter{}, newError(0, "unknown field %q", name)
+	}
+
+	if op == domain.Null || op == domain.NotNull {
+		return domain.Filter{Field: field.Column, Op: op, CaseSensitive: caseSensitive}, nil
+	}
+
+	valueType := field.Type
+
+	if valueType == domain.DateTime && utf8.RuneCountInString(raw) == dateLen {
+		valueType = domain.Date
+	}
+
+	value, err := repo.CastFilterList(raw, separator, valueType, op, caseSensitive)
+	if err != nil {
+		return domain.Filter{}, newError(0, "invalid %s value %q for field %q", typeName(field.Type), raw, name)
+	}
+
+	return domain.Filter{Field: field.Column, Op: op, Value: value, CaseSensitive: caseSensitive}, nil
+}
+
+type filterParser struct {
+	tokens []token
+	pos    int
+	depth  int
+	schema Schema
+}
+
+func (p *filterParser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *filterParser) next() token {
+	tok := p.tokens[p.pos]
+
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+
+	return tok
+}
+
+// parseExpression parses factors joined by AND, explicit or implicit.
+func (p *filterParser) parseExpression() (domain.Condition, error) {
+	conditions := make([]domain.Condition, 0, 1)
+
+	for {
+		condition, err := p.parseFactor()
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, condition)
+
+		switch p.peek().kind {
+		case tokenAnd:
+			p.next()
+		case tokenText, tokenLParen, tokenNot, tokenMinus:
+			// Juxtaposed terms are implicitly combined with AND.
+		default:
+			return combine(domain.And, conditions), nil
+		}
+	}
+}
+
+// parseFactor parses terms joined by OR.
+func (p *filterParser) parseFactor() (domain.Condition, error) {
+	conditions := make([]domain.Condition, 0, 1)
+
+	for {
+		condition, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+
+		conditions = append(conditions, condition)
+
+		if p.peek().kind != tokenOr {
+			return combine(domain.Or, conditions), nil
+		}
+
+		p.next()
+	}
+}
+
+// parseTerm parses a simple term, possibly negated by NOT or a leading -.
+func (p *filterParser) parseTerm() (domain.Condition, error) {
+	if kind := p.peek().kind; kind != tokenNot && kind != tokenMinus {
+		return p.parseSimple()
+	}
+
+	tok := p.next()
+
+	if err := p.enter(tok); err != nil {
+		return nil, err
+	}
+	defer p.leave()
+
+	condition, err := p.parseSimple()
+	if err != nil {
+		return nil, err
+	}
+
+	return domain.Negate(condition), nil
+}
+
+// parseSimple parses a restriction or a parenthesised expression.
+func (p *filterParser) parseSimple() (domain.Condition, error) {
+	tok := p.peek()
+
+	switch tok.kind {
+	case tokenLParen:
+		p.next()
+
+		if err := p.enter(tok); err != nil {
+			return nil, err
+		}
+		defer p.leave()
+
+		condition, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.next(); closing.kind != tokenRParen {
+			return nil, newError(closing.column, "expected \")\" to close \"(\" at column %d, got %s", tok.column, closing)
+		}
+
+		return condition, nil
+	case tokenText:
+		return p.parseRestriction()
+	default:
+		return nil, newError(tok.column, "expected a field or \"(\", got %s", tok)
+	}
+}
+
+func (p *filterParser) parseRestriction() (domain.Condition, error) {
+	fieldTok := p.next()
+
+	field, ok := p.schema[fieldTok.value]
+	if !ok {
+		return nil, newError(fieldTok.column, "unknown field %q", fieldTok.value)
+	}
+
+	comparatorTok := p.next()
+	if comparatorTok.kind != tokenComparator {
+		return nil, newError(comparatorTok.column, "expected a comparator after field %q, got %s",
+			fieldTok.value, comparatorTok)
+	}
+
+	valueTok := p.next()
+	if valueTok.kind != tokenText && valueTok.kind != tokenString {
+		return nil, newError(valueTok.column, "expected a value after %q, got %s", comparatorTok.value, valueTok)
+	}
+
+	op, raw, err := restrictionOp(field, comparatorTok, valueTok)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, err := p.schema.Filter(fieldTok.value, op, raw, false)
+	if err != nil {
+		var parseErr *Error
+		if errors.As(err, &parseErr) {
+			parseErr.Column = valueTok.column
+		}
+
+		return nil, err
+	}
+
+	return filter, nil
+}
+
+// restrictionOp resolves the filter operator of a restriction and strips the wildcards off its value.
+func restrictionOp(field Field, comparatorTok, valueTok token) (domain.Op, string, error) {
+	raw := valueTok.value
+	quoted := valueTok.kind == tokenString
+
+	if !quoted && raw == nullValue {
+		switch comparatorTok.value {
+		case "=":
+			return domain.Null, "", nil
+		case "!=":
+			return domain.NotNull, "", nil
+		default:
+			return 0, "", newError(comparatorTok.column, "null can only be compared with = or !=")
+		}
+	}
+
+	if field.Type == domain.Boolean && comparatorTok.value != "=" && comparatorTok.value != "!=" &&
+		comparatorTok.value != ":" {
+		return 0, "", newError(comparatorTok.column, "booleans can only be compared with =, != or :")
+	}
+
+	if field.Type == domain.String {
+		prefix := strings.HasPrefix(raw, wildcard)
+		suffix := len(raw) > 1 && strings.HasSuffix(raw, wildcard)
+		trimmed := strings.TrimSuffix(strings.TrimPrefix(raw, wildcard), wildcard)
+
+		switch {
+		case comparatorTok.value == ":":
+			return domain.Contains, raw, nil
+		case comparatorTok.value == "=" && prefix && suffix:
+			return domain.Contains, trimmed, nil
+		case comparatorTok.value == "=" && prefix:
+			return domain.EndsWith, trimmed, nil
+		case comparatorTok.value == "=" && suffix:
+			return domain.StartsWith, trimmed, nil
+		case comparatorTok.value == "!=" && prefix && suffix:
+			return domain.NotContains, trimmed, nil
+		case prefix || suffix:
+			return 0, "", newError(valueTok.column, "wildcards are only supported with =, or with != around the value")
+		}
+	}
+
+	return comparatorOps[comparatorTok.value], raw, nil
+}
+
+var comparatorOps = map[string]domain.Op{
+	"=":  domain.Eq,
+	"!=": domain.Ne,
+	"<":  domain.Lt,
+	"<=": domain.Le,
+	">":  domain.Gt,
+	">=": domain.Ge,
+	":":  domain.Eq,
+}
+
+func (p *filterParser) enter(tok token) error {
+	p.depth++
+
+	if p.depth > maxDepth {
+		return newError(tok.column, "expression is nested deeper than %d levels", maxDepth)
+	}
+
+	return nil
+}
+
+func (p *filterParser) leave() {
+	p.depth--
+}
+
+func combine(op domain.GroupOp, conditions []domain.Condition) domain.Condition {
+	if len(conditions) == 1 {
+		return conditions[0]
+	}
+
+	return domain.FilterGroup{Op: op, Conditions: conditions}
+}
+
+func typeName(valueType domain.FilterValueType) string {
+	switch valueType {
+	case domain.String:
+		return "string"
+	case domain.Integer:
+		return "integer"
+	case domain.Float:
+		return "float"
+	case domain.Boolean:
+		return "boolean"
+	case domain.Date:
+		return "date"
+	case domain.Time:
+		return "time"
+	case domain.DateTime:
+		return "date time"
+	case domain.UUID:
+		return "uuid"
+	default:
+		return "unknown"
+	}
+}
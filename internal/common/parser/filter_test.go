@@ -0,0 +1,286 @@
+package parser
+
+import (
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/fixture"
+	"github.com/vnworkday/account/internal/common/repo"
+)
+
+var testSchema = Schema{
+	"name":       {Column: "name", Type: domain.String},
+	"domain":     {Column: "port", Type: domain.String},
+	"status":     {Column: "status", Type: domain.Integer},
+	"rating":     {Column: "rating", Type: domain.Float},
+	"enabled":    {Column: "self_registration_enabled", Type: domain.Boolean},
+	"created_at": {Column: "created_at", Type: domain.DateTime},
+}
+
+func TestParseFilter(t *testing.T) {
+	t.Parallel()
+
+	createdAt, _ := time.ParseInLocation("2006-01-02", "2024-01-01", time.Local)
+	updatedAt, _ := time.ParseInLocation("2006-01-02 15:04:05", "2024-01-01 10:30:00", time.Local)
+
+	tests := []struct {
+		name    string
+		input   string
+		want    domain.Condition
+		wantErr bool
+	}{
+		{
+			name:  "EmptyInput",
+			input: "   ",
+			want:  nil,
+		},
+		{
+			name:  "SingleRestriction",
+			input: "status = 1",
+			want:  domain.Filter{Field: "status", Op: domain.Eq, Value: 1},
+		},
+		{
+			name:  "RenamedColumn",
+			input: `domain = "acme.vnworkday.com"`,
+			want:  domain.Filter{Field: "port", Op: domain.Eq, Value: "acme.vnworkday.com"},
+		},
+		{
+			name:  "ConjunctionWithUnicodeAndDates",
+			input: `status = 1 AND name:"Công ty" AND created_at > "2024-01-01"`,
+			want: domain.AllOf(
+				domain.Filter{Field: "status", Op: domain.Eq, Value: 1},
+				domain.Filter{Field: "name", Op: domain.Contains, Value: "Công ty"},
+				domain.Filter{Field: "created_at", Op: domain.Gt, Value: createdAt},
+			),
+		},
+		{
+			name:  "DateTimeValue",
+			input: `created_at <= "2024-01-01 10:30:00"`,
+			want:  domain.Filter{Field: "created_at", Op: domain.Le, Value: updatedAt},
+		},
+		{
+			name:  "OrBindsTighterThanAnd",
+			input: "status = 1 AND status = 2 OR enabled = true",
+			want: domain.AllOf(
+				domain.Filter{Field: "status", Op: domain.Eq, Value: 1},
+				domain.AnyOf(
+					domain.Filter{Field: "status", Op: domain.Eq, Value: 2},
+					domain.Filter{Field: "self_registration_enabled", Op: domain.Eq, Value: true},
+				),
+			),
+		},
+		{
+			name:  "ImplicitAnd",
+			input: "status >= 1 rating < 4.5",
+			want: domain.AllOf(
+				domain.Filter{Field: "status", Op: domain.Ge, Value: 1},
+				domain.Filter{Field: "rating", Op: domain.Lt, Value: 4.5},
+			),
+		},
+		{
+			name:  "ParenthesesAndWildcard",
+			input: `status = 1 OR (status = 2 AND name = "VN*")`,
+			want: domain.AnyOf(
+				domain.Filter{Field: "status", Op: domain.Eq, Value: 1},
+				domain.AllOf(
+					domain.Filter{Field: "status", Op: domain.Eq, Value: 2},
+					domain.Filter{Field: "name", Op: domain.StartsWith, Value: "VN"},
+				),
+			),
+		},
+		{
+			name:  "SuffixAndSubstringWildcards",
+			input: `name = "*Ltd" name != "*test*"`,
+			want: domain.AllOf(
+				domain.Filter{Field: "name", Op: domain.EndsWith, Value: "Ltd"},
+				domain.Filter{Field: "name", Op: domain.NotContains, Value: "test"},
+			),
+		},
+		{
+			name:  "Negations",
+			input: "NOT enabled = true -status = -1",
+			want: domain.AllOf(
+				domain.Negate(domain.Filter{Field: "self_registration_enabled", Op: domain.Eq, Value: true}),
+				domain.Negate(domain.Filter{Field: "status", Op: domain.Eq, Value: -1}),
+			),
+		},
+		{
+			name:  "NullChecks",
+			input: "name = null AND domain != null",
+			want: domain.AllOf(
+				domain.Filter{Field: "name", Op: domain.Null},
+				domain.Filter{Field: "port", Op: domain.NotNull},
+			),
+		},
+		{
+			name:  "QuotedNullIsAString",
+			input: `name = "null"`,
+			want:  domain.Filter{Field: "name", Op: domain.Eq, Value: "null"},
+		},
+		{
+			name:  "EscapedQuotes",
+			input: `name = 'O\'Neil "Co"'`,
+			want:  domain.Filter{Field: "name", Op: domain.Eq, Value: `O'Neil "Co"`},
+		},
+		{
+			name:  "HasOnNonStringMeansEquals",
+			input: "status:3",
+			want:  domain.Filter{Field: "status", Op: domain.Eq, Value: 3},
+		},
+		{
+			name:    "UnknownField",
+			input:   "password = 1",
+			wantErr: true,
+		},
+		{
+			name:    "InvalidInteger",
+			input:   "status = abc",
+			wantErr: true,
+		},
+		{
+			name:    "OrderingOnBoolean",
+			input:   "enabled > true",
+			wantErr: true,
+		},
+		{
+			name:    "NullWithOrdering",
+			input:   "name < null",
+			wantErr: true,
+		},
+		{
+			name:    "MisplacedWildcard",
+			input:   `name < "VN*"`,
+			wantErr: true,
+		},
+		{
+			name:    "DanglingOperator",
+			input:   "status = 1 AND",
+			wantErr: true,
+		},
+		{
+			name:    "UnbalancedParentheses",
+			input:   "(status = 1",
+			wantErr: true,
+		},
+		{
+			name:    "TooDeep",
+			input:   "((((((((((((((((((((((((((((((((((status = 1))))))))))))))))))))))))))))))))))",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotErr := ParseFilter(tt.input, testSchema)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestParseFilter_ErrorColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{
+			name:  "UnknownFieldAfterUnicode",
+			input: `name:"Công ty" AND secret = 1`,
+			want:  20,
+		},
+		{
+			name:  "InvalidValue",
+			input: "status = 1.5",
+			want:  10,
+		},
+		{
+			name:  "MissingComparator",
+			input: "status 1",
+			want:  8,
+		},
+		{
+			name:  "UnterminatedString",
+			input: `name = "Công`,
+			want:  8,
+		},
+		{
+			name:  "UnexpectedEnd",
+			input: "status =",
+			want:  9,
+		},
+		{
+			name:  "UnexpectedClosingParenthesis",
+			input: "status = 1)",
+			want:  11,
+		},
+		{
+			name:  "LoneBang",
+			input: "status ! 1",
+			want:  8,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, gotErr := ParseFilter(tt.input, testSchema)
+
+			var parseErr *Error
+
+			assert.DisableColor()
+			assert.True(t, errors.As(gotErr, &parseErr))
+			assert.Equal(t, tt.want, parseErr.Column)
+		})
+	}
+}
+
+func FuzzParseFilter(f *testing.F) {
+	seeds := []string{
+		`status = 1 AND name:"Công ty" AND created_at > "2024-01-01"`,
+		`status = 1 OR (status = 2 AND name = "VN*")`,
+		`NOT enabled = true -status = -1`,
+		`name = null AND domain != null`,
+		`rating >= 4.5 rating < 5`,
+		`((name = 'a\'b'))`,
+		`status = 1)`,
+		`"`,
+	}
+
+	for _, seed := range seeds {
+		f.Add(seed)
+	}
+
+	f.Fuzz(func(t *testing.T, input string) {
+		condition, err := ParseFilter(input, testSchema)
+		if err != nil {
+			var parseErr *Error
+			if !errors.As(err, &parseErr) {
+				t.Fatalf("unexpected error type %T: %v", err, err)
+			}
+
+			if parseErr.Column < 1 || parseErr.Column > utf8.RuneCountInString(input)+1 {
+				t.Fatalf("column %d out of range for %q", parseErr.Column, input)
+			}
+
+			return
+		}
+
+		if condition == nil {
+			return
+		}
+
+		if _, err = repo.StringifyFilter(condition); err != nil {
+			t.Fatalf("parsed %q into a condition that cannot be rendered: %v", input, err)
+		}
+	})
+}
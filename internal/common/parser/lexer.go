@@ -0,0 +1,163 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenText
+	tokenString
+	tokenLParen
+	tokenRParen
+	tokenComparator
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenMinus
+)
+
+type token struct {
+	kind   tokenKind
+	value  string
+	column int
+}
+
+func (t token) String() string {
+	switch t.kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenString:
+		return "string \"" + t.value + "\""
+	default:
+		return "\"" + t.value + "\""
+	}
+}
+
+var comparators = []string{"<=", ">=", "!=", "=", "<", ">", ":"}
+
+// tokenize splits the input into tokens. Columns are counted in characters, starting from 1.
+func tokenize(input string) ([]token, error) {
+	runes := []rune(input)
+	tokens := make([]token, 0)
+
+	for idx := 0; idx < len(runes); {
+		char := runes[idx]
+		column := idx + 1
+
+		switch {
+		case unicode.IsSpace(char):
+			idx++
+		case char == '(':
+			tokens = append(tokens, token{kind: tokenLParen, value: "(", column: column})
+			idx++
+		case char == ')':
+			tokens = append(tokens, token{kind: tokenRParen, value: ")", column: column})
+			idx++
+		case char == '"' || char == '\'':
+			value, next, err := readQuoted(runes, idx)
+			if err != nil {
+				return nil, err
+			}
+
+			tokens = append(tokens, token{kind: tokenString, value: value, column: column})
+			idx = next
+		case isComparatorStart(char):
+			comparator := readComparator(runes, idx)
+			if comparator == "" {
+				return nil, newError(column, "unexpected character %q", char)
+			}
+
+			tokens = append(tokens, token{kind: tokenComparator, value: comparator, column: column})
+			idx += len(comparator)
+		case char == '-' && isNegation(runes, idx, tokens):
+			tokens = append(tokens, token{kind: tokenMinus, value: "-", column: column})
+			idx++
+		default:
+			value, next := readText(runes, idx)
+
+			tokens = append(tokens, token{kind: keywordKind(value), value: value, column: column})
+			idx = next
+		}
+	}
+
+	return append(tokens, token{kind: tokenEOF, column: len(runes) + 1}), nil
+}
+
+// readQuoted reads a string enclosed in the quote found at start. A backslash escapes the next character.
+func readQuoted(runes []rune, start int) (string, int, error) {
+	var sb strings.Builder
+
+	quote := runes[start]
+
+	for idx := start + 1; idx < len(runes); idx++ {
+		switch runes[idx] {
+		case '\\':
+			if idx+1 < len(runes) {
+				idx++
+				sb.WriteRune(runes[idx])
+			}
+		case quote:
+			return sb.String(), idx + 1, nil
+		default:
+			sb.WriteRune(runes[idx])
+		}
+	}
+
+	return "", 0, newError(start+1, "unterminated string")
+}
+
+func readComparator(runes []rune, start int) string {
+	for _, comparator := range comparators {
+		end := start + len(comparator)
+
+		if end <= len(runes) && string(runes[start:end]) == comparator {
+			return comparator
+		}
+	}
+
+	return ""
+}
+
+func readText(runes []rune, start int) (string, int) {
+	idx := start
+
+	for idx < len(runes) && !isTextBoundary(runes[idx]) {
+		idx++
+	}
+
+	return string(runes[start:idx]), idx
+}
+
+// isNegation tells a leading "-" that negates the next term apart from the sign of a number or a text value.
+func isNegation(runes []rune, idx int, previous []token) bool {
+	if len(previous) > 0 && previous[len(previous)-1].kind == tokenComparator {
+		return false
+	}
+
+	return idx+1 < len(runes) && !unicode.IsDigit(runes[idx+1]) && !unicode.IsSpace(runes[idx+1])
+}
+
+func isComparatorStart(char rune) bool {
+	return strings.ContainsRune("<>!=:", char)
+}
+
+func isTextBoundary(char rune) bool {
+	return unicode.IsSpace(char) || strings.ContainsRune("()\"'<>!=:", char)
+}
+
+func keywordKind(value string) tokenKind {
+	switch value {
+	case "AND":
+		return tokenAnd
+	case "OR":
+		return tokenOr
+	case "NOT":
+		return tokenNot
+	default:
+		return tokenText
+	}
+}
@@ -0,0 +1,35 @@
+package parser
+
+import (
+	"strings"
+
+	"github.com/gookit/goutil/arrutil"
+	"github.com/vnworkday/account/internal/common/domain"
+)
+
+// ParseFieldMask validates the paths of an update mask, such as "name, subscription_type", against the fields
+// that the update may change. Paths are trimmed and deduplicated; empty paths are ignored, so that an empty
+// input gives an empty mask, which stands for every field.
+func ParseFieldMask(input string, fields []string) (domain.FieldMask, error) {
+	return NewFieldMask(strings.Split(input, ","), fields)
+}
+
+// NewFieldMask is ParseFieldMask for paths that are already split, as in a google.protobuf.FieldMask.
+func NewFieldMask(paths []string, fields []string) (domain.FieldMask, error) {
+	mask := make(domain.FieldMask, 0, len(paths))
+
+	for _, path := range paths {
+		path = strings.TrimSpace(path)
+
+		switch {
+		case path == "", arrutil.Contains(mask, path):
+			continue
+		case !arrutil.Contains(fields, path):
+			return nil, newError(0, "field %q cannot be updated", path)
+		}
+
+		mask = append(mask, path)
+	}
+
+	return mask, nil
+}
@@ -0,0 +1,56 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+func TestParseFieldMask(t *testing.T) {
+	t.Parallel()
+
+	fields := []string{"name", "status"}
+
+	tests := []struct {
+		name    string
+		input   string
+		want    domain.FieldMask
+		wantErr bool
+	}{
+		{
+			name:    "EmptyInput",
+			input:   "",
+			want:    domain.FieldMask{},
+			wantErr: false,
+		},
+		{
+			name:    "TrimsAndDeduplicates",
+			input:   " status, name ,status,",
+			want:    domain.FieldMask{"status", "name"},
+			wantErr: false,
+		},
+		{
+			name:    "ImmutableField",
+			input:   "name,id",
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "UnknownField",
+			input:   "nickname",
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotErr := ParseFieldMask(tt.input, fields)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package parser
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/gookit/goutil/arrutil"
+	"github.com/vnworkday/account/internal/common/domain"
+)
+
+// ParseOrderBy parses an AIP-132 style order_by expression, such as "name desc, created_at", into sorts over
+// the allowed columns. Every item is a column name optionally followed by asc or desc (ascending by default),
+// and a column may appear only once. Column names never reach the query unless they are in the allow-list.
+func ParseOrderBy(input string, columns []string) ([]domain.Sort, error) {
+	sorts := make([]domain.Sort, 0)
+
+	if strings.TrimSpace(input) == "" {
+		return sorts, nil
+	}
+
+	column := 1
+
+	for _, item := range strings.Split(input, ",") {
+		sort, err := parseOrderItem(item, column, columns)
+		if err != nil {
+			return nil, err
+		}
+
+		if arrutil.Contains(sortFields(sorts), sort.Field) {
+			return nil, newError(splitWords(item, column)[0].column, "field %q is sorted more than once", sort.Field)
+		}
+
+		sorts = append(sorts, sort)
+		column += utf8.RuneCountInString(item) + 1
+	}
+
+	return sorts, nil
+}
+
+// NewSort validates a single field and order pair, as sent by clients that do not use order_by expressions.
+// An empty order means ascending.
+func NewSort(field, order string, columns []string) (domain.Sort, error) {
+	if !arrutil.Contains(columns, field) {
+		return domain.Sort{}, newError(0, "cannot sort by %q", field)
+	}
+
+	switch strings.ToLower(order) {
+	case "", string(domain.Asc):
+		return domain.Sort{Field: field, Order: domain.Asc}, nil
+	case string(domain.Desc):
+		return domain.Sort{Field: field, Order: domain.Desc}, nil
+	default:
+		return domain.Sort{}, newError(0, "invalid sort order %q for field %q", order, field)
+	}
+}
+
+// StableSort completes the sorts with the defaults whose fields are not sorted yet, so that rows always come
+// back in the same order, which keeps pagination deterministic. The defaults should end with a unique column.
+func StableSort(sorts []domain.Sort, defaults ...domain.Sort) []domain.Sort {
+	stable := make([]domain.Sort, 0, len(sorts)+len(defaults))
+	stable = append(stable, sorts...)
+
+	for _, sort := range defaults {
+		if !arrutil.Contains(sortFields(stable), sort.Field) {
+			stable = append(stable, sort)
+		}
+	}
+
+	return stable
+}
+
+func parseOrderItem(item string, column int, columns []string) (domain.Sort, error) {
+	words := splitWords(item, column)
+
+	switch {
+	case len(words) == 0:
+		return domain.Sort{}, newError(column+utf8.RuneCountInString(item), "expected a field name")
+	case len(words) > 2:
+		return domain.Sort{}, newError(words[2].column, "unexpected %q", words[2].value)
+	case !arrutil.Contains(columns, words[0].value):
+		return domain.Sort{}, newError(words[0].column, "cannot sort by %q", words[0].value)
+	case len(words) == 1:
+		return domain.Sort{Field: words[0].value, Order: domain.Asc}, nil
+	}
+
+	sort, err := NewSort(words[0].value, words[1].value, columns)
+	if err != nil {
+		return domain.Sort{}, newError(words[1].column, "expected asc or desc after %q", words[0].value)
+	}
+
+	return sort, nil
+}
+
+// splitWords splits the item on white space and records the column of every word, counting from column.
+func splitWords(item string, column int) []token {
+	words := make([]token, 0)
+	runes := []rune(item)
+
+	for idx := 0; idx < len(runes); idx++ {
+		if unicode.IsSpace(runes[idx]) {
+			continue
+		}
+
+		start := idx
+
+		for idx < len(runes) && !unicode.IsSpace(runes[idx]) {
+			idx++
+		}
+
+		words = append(words, token{kind: tokenText, value: string(runes[start:idx]), column: column + start})
+	}
+
+	return words
+}
+
+func sortFields(sorts []domain.Sort) []string {
+	return arrutil.Map(sorts, func(sort domain.Sort) (string, bool) {
+		return sort.Field, true
+	})
+}
@@ -0,0 +1,183 @@
+package parser
+
+import (
+	"testing"
+
+	"github.com/gookit/goutil/testutil/assert"
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+var testColumns = []string{"id", "name", "status", "created_at"}
+
+func TestParseOrderBy(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    []domain.Sort
+		wantErr bool
+	}{
+		{
+			name:  "EmptyInput",
+			input: " ",
+			want:  []domain.Sort{},
+		},
+		{
+			name:  "DefaultsToAscending",
+			input: "name",
+			want:  []domain.Sort{{Field: "name", Order: domain.Asc}},
+		},
+		{
+			name:  "MultipleFields",
+			input: "name desc, created_at",
+			want: []domain.Sort{
+				{Field: "name", Order: domain.Desc},
+				{Field: "created_at", Order: domain.Asc},
+			},
+		},
+		{
+			name:  "CaseInsensitiveOrder",
+			input: "  status DESC ,id Asc",
+			want: []domain.Sort{
+				{Field: "status", Order: domain.Desc},
+				{Field: "id", Order: domain.Asc},
+			},
+		},
+		{
+			name:    "UnknownField",
+			input:   "name, password desc",
+			wantErr: true,
+		},
+		{
+			name:    "SQLInjection",
+			input:   "name; DROP TABLE tenant",
+			wantErr: true,
+		},
+		{
+			name:    "InvalidOrder",
+			input:   "name descending",
+			wantErr: true,
+		},
+		{
+			name:    "DuplicateField",
+			input:   "name desc, name",
+			wantErr: true,
+		},
+		{
+			name:    "TrailingComma",
+			input:   "name,",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotErr := ParseOrderBy(tt.input, testColumns)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestParseOrderBy_ErrorColumn(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  int
+	}{
+		{
+			name:  "UnknownField",
+			input: "name, password desc",
+			want:  7,
+		},
+		{
+			name:  "InvalidOrder",
+			input: "name  up",
+			want:  7,
+		},
+		{
+			name:  "ExtraWord",
+			input: "name asc nulls",
+			want:  10,
+		},
+		{
+			name:  "DuplicateField",
+			input: "id, id desc",
+			want:  5,
+		},
+		{
+			name:  "EmptyItem",
+			input: "id,,name",
+			want:  4,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			_, gotErr := ParseOrderBy(tt.input, testColumns)
+
+			var parseErr *Error
+
+			assert.DisableColor()
+			assert.True(t, errors.As(gotErr, &parseErr))
+			assert.Equal(t, tt.want, parseErr.Column)
+		})
+	}
+}
+
+func TestStableSort(t *testing.T) {
+	t.Parallel()
+
+	defaults := []domain.Sort{
+		{Field: "created_at", Order: domain.Asc},
+		{Field: "id", Order: domain.Asc},
+	}
+
+	tests := []struct {
+		name  string
+		input []domain.Sort
+		want  []domain.Sort
+	}{
+		{
+			name:  "NoSorts",
+			input: nil,
+			want:  defaults,
+		},
+		{
+			name:  "AppendsMissingDefaults",
+			input: []domain.Sort{{Field: "name", Order: domain.Desc}},
+			want: []domain.Sort{
+				{Field: "name", Order: domain.Desc},
+				{Field: "created_at", Order: domain.Asc},
+				{Field: "id", Order: domain.Asc},
+			},
+		},
+		{
+			name:  "KeepsClientOrderOfDefaultFields",
+			input: []domain.Sort{{Field: "created_at", Order: domain.Desc}},
+			want: []domain.Sort{
+				{Field: "created_at", Order: domain.Desc},
+				{Field: "id", Order: domain.Asc},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := StableSort(tt.input, defaults...)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, false, nil)
+		})
+	}
+}
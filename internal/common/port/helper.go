@@ -21,6 +21,8 @@ func Delegate[T any, R any](ctx context.Context, request *T, doer endpoint.Endpo
 	return castResp, nil
 }
 
+// MakeEndpoint turns the use case into an endpoint wrapped in the middlewares, each of which wraps the ones
+// given before it.
 func MakeEndpoint[T any, R any](
 	does func(ctx context.Context, request *T) (*R, error),
 	middlewares ...endpoint.Middleware,
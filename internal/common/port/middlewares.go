@@ -8,6 +8,8 @@ import (
 
 	"github.com/go-kit/kit/endpoint"
 	"github.com/go-kit/kit/metrics"
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/repo"
 	"go.uber.org/zap"
 )
 
@@ -34,3 +36,41 @@ func InstrumentingMiddleware(duration metrics.Histogram) endpoint.Middleware {
 		}
 	}
 }
+
+// ValidationMiddleware rejects the requests that fail validate before they reach the use case.
+func ValidationMiddleware[T any](validate func(ctx context.Context, request *T) error) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request any) (any, error) {
+			req, ok := request.(*T)
+			if !ok {
+				return nil, errors.New("invalid request")
+			}
+
+			if err := validate(ctx, req); err != nil {
+				return nil, err
+			}
+
+			return next(ctx, request)
+		}
+	}
+}
+
+// TransactionMiddleware runs the endpoint in one transaction, so that a validation that it wraps, given before it
+// to MakeEndpoint, sees the same data as the use case. Transactions that the use case opens itself become
+// savepoints of it.
+func TransactionMiddleware(uow repo.UnitOfWork, opts ...repo.TxOption) endpoint.Middleware {
+	return func(next endpoint.Endpoint) endpoint.Endpoint {
+		return func(ctx context.Context, request any) (response any, err error) {
+			err = uow.Do(ctx, func(ctx context.Context) error {
+				response, err = next(ctx, request)
+
+				return err
+			}, opts...)
+			if err != nil {
+				return nil, err
+			}
+
+			return response, nil
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+)
+
+// maxBindParameters is the number of bind parameters that PostgreSQL accepts in one statement.
+const maxBindParameters = 65535
+
+// batchRows converts the entities into rows holding the values of the given columns, or of all their tagged
+// fields when no column is given, and returns the columns of the rows.
+func batchRows[T any](values []*T, columns []string) ([]string, [][]any, error) {
+	if len(values) == 0 {
+		return nil, nil, errors.New("repository: values are required")
+	}
+
+	rows := make([][]any, 0, len(values))
+
+	for _, value := range values {
+		setters, err := toInsertSetters(value)
+		if err != nil {
+			return nil, nil, errors.Wrap(err, "repository: failed to convert values to setters")
+		}
+
+		if len(columns) == 0 {
+			for _, setter := range setters {
+				columns = append(columns, setter.Field)
+			}
+		}
+
+		if setters, err = PickSetters(setters, columns...); err != nil {
+			return nil, nil, err
+		}
+
+		row := make([]any, 0, len(setters))
+
+		for _, setter := range setters {
+			row = append(row, setter.Value)
+		}
+
+		rows = append(rows, row)
+	}
+
+	return columns, rows, nil
+}
+
+// chunkRows splits the rows into batches of at most size rows, and of at most as many rows as fit in the bind
+// parameter limit of a statement.
+func chunkRows(rows [][]any, columns int, size int) [][][]any {
+	limit := maxBindParameters / max(columns, 1)
+
+	if size <= 0 || size > limit {
+		size = limit
+	}
+
+	chunks := make([][][]any, 0, (len(rows)+size-1)/size)
+
+	for start := 0; start < len(rows); start += size {
+		chunks = append(chunks, rows[start:min(start+size, len(rows))])
+	}
+
+	return chunks
+}
+
+// requireTx refuses to run a batch of several statements outside a transaction, where a failing statement would
+// leave the previous ones applied.
+func requireTx(db Executor, statements int) error {
+	if _, ok := db.(*sql.Tx); ok || statements <= 1 {
+		return nil
+	}
+
+	return errors.Errorf("repository: a batch of %d statements must run in a transaction, as provided by UnitOfWork",
+		statements)
+}
+
+// stringifyRows renders a VALUES list of the rows, with a placeholder for every value but expressions.
+func stringifyRows(rows [][]any) string {
+	values := make([]string, 0, len(rows))
+
+	for _, row := range rows {
+		markers := make([]string, 0, len(row))
+
+		for _, value := range row {
+			if expr, ok := value.(Expr); ok {
+				markers = append(markers, string(expr))
+			} else {
+				markers = append(markers, string(placeholder))
+			}
+		}
+
+		values = append(values, "("+strings.Join(markers, ", ")+")")
+	}
+
+	return strings.Join(values, ", ")
+}
+
+// flattenRows returns the bind parameters of the rows, which are all their values but expressions.
+func flattenRows(rows [][]any) []any {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	args := make([]any, 0, len(rows)*len(rows[0]))
+
+	for _, row := range rows {
+		for _, value := range row {
+			if _, ok := value.(Expr); !ok {
+				args = append(args, value)
+			}
+		}
+	}
+
+	return args
+}
+
+// CopyFrom bulk loads the entities into the table with COPY FROM STDIN, which is much faster than INSERT for
+// large imports but cannot handle conflicts. It loads the given columns, or all tagged fields when no column
+// is given, and must run in a transaction, as provided by UnitOfWork.
+func CopyFrom[T any](ctx context.Context, db Executor, table string, values []*T, columns ...string) (int64, error) {
+	tx, ok := db.(*sql.Tx)
+	if !ok {
+		return -1, errors.New("repository: copy from must run in a transaction")
+	}
+
+	columns, rows, err := batchRows(values, columns)
+	if err != nil {
+		return -1, err
+	}
+
+	for _, row := range rows {
+		for idx, value := range row {
+			if _, ok := value.(Expr); ok {
+				return -1, errors.Errorf("repository: copy from cannot use the default of column %s", columns[idx])
+			}
+		}
+	}
+
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(table, columns...))
+	if err != nil {
+		return -1, errors.Wrapf(err, "repository: cannot copy into %s", table)
+	}
+
+	defer func() {
+		_ = stmt.Close()
+	}()
+
+	for _, row := range rows {
+		if _, err = stmt.ExecContext(ctx, row...); err != nil {
+			return -1, errors.Wrapf(err, "repository: cannot copy into %s", table)
+		}
+	}
+
+	result, err := stmt.ExecContext(ctx)
+	if err != nil {
+		return -1, errors.Wrapf(err, "repository: cannot copy into %s", table)
+	}
+
+	return result.RowsAffected()
+}
@@ -0,0 +1,162 @@
+package repo
+
+import (
+	"context"
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+type testTag struct {
+	Name string `db:"name"`
+}
+
+func testTags(names ...string) []*testTag {
+	tags := make([]*testTag, 0, len(names))
+
+	for _, name := range names {
+		tags = append(tags, &testTag{Name: name})
+	}
+
+	return tags
+}
+
+func TestChunkRows(t *testing.T) {
+	t.Parallel()
+
+	rows := make([][]any, 0, 5)
+	for idx := range 5 {
+		rows = append(rows, []any{idx})
+	}
+
+	tests := []struct {
+		name    string
+		rows    [][]any
+		columns int
+		size    int
+		want    []int
+	}{
+		{
+			name:    "ChunkRows With Batch Size",
+			rows:    rows,
+			columns: 1,
+			size:    2,
+			want:    []int{2, 2, 1},
+		},
+		{
+			name:    "ChunkRows Without Batch Size",
+			rows:    rows,
+			columns: 1,
+			size:    0,
+			want:    []int{5},
+		},
+		{
+			name:    "ChunkRows Capped By Bind Parameter Limit",
+			rows:    make([][]any, 70000),
+			columns: 2,
+			size:    100000,
+			want:    []int{32767, 32767, 4466},
+		},
+		{
+			name:    "ChunkRows Without Rows",
+			rows:    nil,
+			columns: 1,
+			size:    2,
+			want:    []int{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got := make([]int, 0)
+			for _, chunk := range chunkRows(tt.rows, tt.columns, tt.size) {
+				got = append(got, len(chunk))
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, false, nil)
+		})
+	}
+}
+
+func TestInsertBuilder_ExecBatch(t *testing.T) {
+	t.Parallel()
+
+	uow, rec := newRecordingUnitOfWork(t)
+
+	var got []int64
+
+	gotErr := uow.Do(context.Background(), func(ctx context.Context) error {
+		var err error
+
+		got, err = NewInsertBuilder[testTag]().
+			Placeholder(Dollar).
+			InsertInto("tags").
+			ValuesBatch(testTags("a", "b", "c", "d", "e")).
+			OnConflictDoNothing("name").
+			BatchSize(2).
+			ExecBatch(ctx, Conn(ctx, nil))
+
+		return err
+	})
+
+	fixture.ExpectationsWereMet(t, []int64{2, 2, 1}, got, false, gotErr)
+	fixture.ExpectationsWereMet(t, []string{
+		"BEGIN",
+		"INSERT INTO tags (name) VALUES ($1), ($2) ON CONFLICT (name) DO NOTHING",
+		"INSERT INTO tags (name) VALUES ($1), ($2) ON CONFLICT (name) DO NOTHING",
+		"INSERT INTO tags (name) VALUES ($1) ON CONFLICT (name) DO NOTHING",
+		"COMMIT",
+	}, rec.Statements(), false, nil)
+}
+
+func TestInsertBuilder_ExecBatchRequiresTransaction(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		size    int
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "SingleBatch",
+			size: 0,
+			want: []string{"INSERT INTO tags (name) VALUES ($1), ($2), ($3)"},
+		},
+		{
+			name:    "SeveralBatches",
+			size:    2,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, rec := newRecordingDB(t)
+
+			_, gotErr := NewInsertBuilder[testTag]().
+				Placeholder(Dollar).
+				InsertInto("tags").
+				ValuesBatch(testTags("a", "b", "c")).
+				BatchSize(tt.size).
+				Exec(context.Background(), db)
+
+			fixture.ExpectationsWereMet(t, tt.want, rec.Statements(), tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestCopyFrom_RequiresTransaction(t *testing.T) {
+	t.Parallel()
+
+	db, _ := newRecordingDB(t)
+
+	got, gotErr := CopyFrom(context.Background(), db, "tags", testTags("a"))
+
+	fixture.ExpectationsWereMet(t, int64(-1), got, true, gotErr)
+}
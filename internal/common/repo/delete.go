@@ -0,0 +1,116 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+)
+
+type DeleteBuilder[T any] struct {
+	deleteClause    string
+	where           whereBuilder
+	returningClause string
+	placeholder     PlaceholderFormat
+	err             error
+}
+
+func NewDeleteBuilder[T any]() *DeleteBuilder[T] {
+	return &DeleteBuilder[T]{}
+}
+
+// Placeholder sets the format of the bind parameters in the built query. Defaults to Question.
+func (b *DeleteBuilder[T]) Placeholder(format PlaceholderFormat) *DeleteBuilder[T] {
+	b.placeholder = format
+
+	return b
+}
+
+func (b *DeleteBuilder[T]) DeleteFrom(table string) *DeleteBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	if table == "" {
+		b.err = errors.New("repository: target table is required")
+
+		return b
+	}
+
+	b.deleteClause = "DELETE FROM " + table
+
+	return b
+}
+
+// Where adds a filter or a filter group to the WHERE clause, combined with the previous ones with AND.
+func (b *DeleteBuilder[T]) Where(condition domain.Condition, optAlias ...string) *DeleteBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	b.err = b.where.add(condition, optAlias...)
+
+	return b
+}
+
+func (b *DeleteBuilder[T]) Returning(columns ...string) *DeleteBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	b.returningClause, b.err = stringifyReturning(columns)
+
+	return b
+}
+
+// build refuses statements without a WHERE clause, which would empty the table.
+func (b *DeleteBuilder[T]) build() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	if b.deleteClause == "" {
+		return "", errors.New("repository: delete clause is required")
+	}
+
+	if b.where.clause.Len() == 0 {
+		return "", errors.New("repository: where clause is required")
+	}
+
+	return placeholderOrDefault(b.placeholder).Replace(b.deleteClause + b.where.clause.String() + b.returningClause)
+}
+
+func (b *DeleteBuilder[T]) String() (string, error) {
+	return b.build()
+}
+
+// Exec runs the delete and returns the number of deleted rows.
+func (b *DeleteBuilder[T]) Exec(ctx context.Context, db Executor) (int64, error) {
+	query, err := b.build()
+	if err != nil {
+		return -1, err
+	}
+
+	return execStatement(ctx, db, query, b.where.args)
+}
+
+// Query runs the delete and scans the row of its RETURNING clause. It returns sql.ErrNoRows when no row
+// matched.
+func (b *DeleteBuilder[T]) Query(ctx context.Context, db Executor, scanners ...Scanner[T]) (*T, error) {
+	query, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+
+	return queryOneStatement(ctx, db, query, b.where.args, scannerOf(scanners))
+}
+
+// QueryAll runs the delete and scans every row of its RETURNING clause.
+func (b *DeleteBuilder[T]) QueryAll(ctx context.Context, db Executor, scanners ...Scanner[T]) ([]*T, error) {
+	query, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+
+	return queryStatement(ctx, db, query, b.where.args, scannerOf(scanners))
+}
@@ -0,0 +1,85 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+func TestDeleteBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Query string
+		Args  []any
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(b *DeleteBuilder[testUser])
+		want    result
+		wantErr bool
+	}{
+		{
+			name: "Build With Where Clause",
+			setup: func(b *DeleteBuilder[testUser]) {
+				b.DeleteFrom("users").
+					Where(domain.Filter{Field: "id", Op: domain.Eq, Value: 1})
+			},
+			want: result{
+				Query: "DELETE FROM users WHERE id = ?",
+				Args:  []any{1},
+			},
+		},
+		{
+			name: "Build With Alias And Returning",
+			setup: func(b *DeleteBuilder[testUser]) {
+				b.Placeholder(Dollar).
+					DeleteFrom("users u").
+					Where(domain.Filter{Field: "created_at", Op: domain.Lt, Value: "2024-01-01"}, "u").
+					Where(domain.Negate(domain.Filter{Field: "email", Op: domain.EndsWith, Value: "@vnworkday.com"}), "u").
+					Returning("u.id")
+			},
+			want: result{
+				Query: "DELETE FROM users u WHERE u.created_at < $1 AND NOT (u.email LIKE '%' || $2) RETURNING u.id",
+				Args:  []any{"2024-01-01", "@vnworkday.com"},
+			},
+		},
+		{
+			name: "Build Without Where Clause",
+			setup: func(b *DeleteBuilder[testUser]) {
+				b.DeleteFrom("users")
+			},
+			wantErr: true,
+		},
+		{
+			name: "Build Without Table",
+			setup: func(b *DeleteBuilder[testUser]) {
+				b.Where(domain.Filter{Field: "id", Op: domain.Eq, Value: 1})
+			},
+			wantErr: true,
+		},
+		{
+			name: "Build With Preceding Error",
+			setup: func(b *DeleteBuilder[testUser]) {
+				b.err = errors.New("forced error")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := NewDeleteBuilder[testUser]()
+			tt.setup(builder)
+
+			query, gotErr := builder.String()
+
+			fixture.ExpectationsWereMet(t, tt.want, result{Query: query, Args: builder.where.args}, tt.wantErr, gotErr)
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+)
+
+// Executor runs statements against the database. It is satisfied by *sql.DB, *sql.Tx and *sql.Conn, so the
+// builders work the same inside and outside a transaction.
+type Executor interface {
+	ExecContext(ctx context.Context, query string, args ...any) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+var (
+	_ Executor = (*sql.DB)(nil)
+	_ Executor = (*sql.Tx)(nil)
+	_ Executor = (*sql.Conn)(nil)
+)
+
+// Conn returns the transaction carried by the context, or db when the context carries none. Repositories pass
+// it to the builders so that they join the unit of work of their caller.
+func Conn(ctx context.Context, db Executor) Executor {
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		return state.tx
+	}
+
+	return db
+}
@@ -2,21 +2,117 @@ package repo
 
 import (
 	"fmt"
+	"reflect"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/vnworkday/account/internal/common/domain"
 
+	"github.com/google/uuid"
 	"github.com/gookit/goutil/strutil"
+	"github.com/lib/pq"
 	"github.com/pkg/errors"
 )
 
 const (
 	keyValueSplitLen = 2
+	betweenBoundsLen = 2
 )
 
-func StringifyFilter(filter domain.Filter, optAlias ...string) (string, error) {
+func StringifyFilter(condition domain.Condition, optAlias ...string) (string, error) {
+	clause, _, err := stringifyCondition(condition, false, optAlias...)
+
+	return clause, err
+}
+
+// stringifyCondition renders a filter or a filter group along with its bind arguments. Groups with more than
+// one condition are parenthesised so that they keep their meaning whatever they are combined with.
+func stringifyCondition(condition domain.Condition, bindArrays bool, optAlias ...string) (string, []any, error) {
+	clause, args, _, err := renderCondition(condition, bindArrays, optAlias...)
+
+	return clause, args, err
+}
+
+// renderCondition also reports whether the rendered clause is already enclosed in parentheses.
+func renderCondition(condition domain.Condition, bindArrays bool, optAlias ...string) (string, []any, bool, error) {
+	switch cond := condition.(type) {
+	case domain.Filter:
+		clause, args, err := stringifyFilter(cond, bindArrays, optAlias...)
+
+		return clause, args, false, err
+	case *domain.Filter:
+		if cond == nil {
+			return "", nil, false, errors.New("repository: filter is required")
+		}
+
+		return renderCondition(*cond, bindArrays, optAlias...)
+	case domain.FilterGroup:
+		return renderFilterGroup(cond, bindArrays, optAlias...)
+	case *domain.FilterGroup:
+		if cond == nil {
+			return "", nil, false, errors.New("repository: filter group is required")
+		}
+
+		return renderFilterGroup(*cond, bindArrays, optAlias...)
+	default:
+		return "", nil, false, errors.Errorf("repository: unsupported filter condition: %T", condition)
+	}
+}
+
+func renderFilterGroup(group domain.FilterGroup, bindArrays bool, optAlias ...string) (string, []any, bool, error) {
+	var separator string
+
+	switch group.Op {
+	case domain.And, domain.Not:
+		separator = " AND "
+	case domain.Or:
+		separator = " OR "
+	default:
+		return "", nil, false, errors.Errorf("repository: unsupported filter group operator: %d", group.Op)
+	}
+
+	if len(group.Conditions) == 0 {
+		return "", nil, false, errors.New("repository: filter group requires at least one condition")
+	}
+
+	clauses := make([]string, 0, len(group.Conditions))
+	args := make([]any, 0, len(group.Conditions))
+	enclosed := false
+
+	for _, condition := range group.Conditions {
+		clause, condArgs, condEnclosed, err := renderCondition(condition, bindArrays, optAlias...)
+		if err != nil {
+			return "", nil, false, err
+		}
+
+		clauses = append(clauses, clause)
+		args = append(args, condArgs...)
+		enclosed = condEnclosed
+	}
+
+	ret := strings.Join(clauses, separator)
+
+	if len(clauses) > 1 {
+		ret = "(" + ret + ")"
+		enclosed = true
+	}
+
+	if group.Op == domain.Not {
+		if !enclosed {
+			ret = "(" + ret + ")"
+		}
+
+		return "NOT " + ret, args, false, nil
+	}
+
+	return ret, args, enclosed, nil
+}
+
+// stringifyFilter renders the filter along with its bind arguments, ordered as their placeholders. Lists
+// of IN and NOT IN filters are either expanded into one placeholder per item, or bound as a single array
+// parameter compared with ANY / ALL when bindArrays is set.
+func stringifyFilter(filter domain.Filter, bindArrays bool, optAlias ...string) (string, []any, error) {
 	var alias string
 
 	if len(optAlias) > 0 {
@@ -24,26 +120,103 @@ func StringifyFilter(filter domain.Filter, optAlias ...string) (string, error) {
 	}
 
 	var field, op, wildcards string
-	var fieldErr, opErr, wildcardsErr error
+	var args []any
+	var fieldErr, opErr, argsErr, wildcardsErr error
 
 	field, fieldErr = stringifyField(filter.Field, filter.CaseSensitive, alias)
 	if fieldErr != nil {
-		return "", errors.Wrap(fieldErr, "repository: failed to stringify filter field")
+		return "", nil, errors.Wrap(fieldErr, "repository: failed to stringify filter field")
 	}
 
 	op, opErr = stringifyOp(filter.Op)
 	if opErr != nil {
-		return "", errors.Wrap(opErr, "repository: failed to stringify filter operator")
+		return "", nil, errors.Wrap(opErr, "repository: failed to stringify filter operator")
+	}
+
+	args, argsErr = filterArgs(filter)
+	if argsErr != nil {
+		return "", nil, errors.Wrap(argsErr, "repository: failed to bind filter value")
 	}
 
 	wildcards, wildcardsErr = buildFilterWildcards(filter.Op, filter.CaseSensitive)
 	if wildcardsErr != nil {
-		return "", errors.Wrap(wildcardsErr, "repository: failed to build filter wildcards")
+		return "", nil, errors.Wrap(wildcardsErr, "repository: failed to build filter wildcards")
+	}
+
+	if filter.Op == domain.In || filter.Op == domain.NotIn {
+		if bindArrays {
+			op = map[domain.Op]string{domain.In: "= ANY(?)", domain.NotIn: "<> ALL(?)"}[filter.Op]
+			wildcards = ""
+			args = []any{pq.Array(args)}
+		} else {
+			wildcards = "(" + strings.TrimSuffix(strings.Repeat("?, ", len(args)), ", ") + ")"
+		}
 	}
 
 	ret := fmt.Sprintf("%s %s %s", field, op, wildcards)
 
-	return strings.TrimSpace(ret), nil
+	return strings.TrimSpace(ret), args, nil
+}
+
+// filterArgs checks that the shape of the filter value matches its operator and flattens it into bind arguments.
+// IN and NOT IN take a non-empty slice, or a single value; BETWEEN takes a slice of exactly two bounds; NULL
+// checks take no value at all; every other operator takes a single value.
+func filterArgs(filter domain.Filter) ([]any, error) {
+	items, isList := listItems(filter.Value)
+
+	switch filter.Op {
+	case domain.Null, domain.NotNull:
+		if filter.Value != nil {
+			return nil, errors.Errorf("repository: operator %d on field %s takes no value", filter.Op, filter.Field)
+		}
+
+		return nil, nil
+	case domain.In, domain.NotIn:
+		if !isList {
+			return []any{filter.Value}, nil
+		}
+
+		if len(items) == 0 {
+			return nil, errors.Errorf("repository: operator %d on field %s requires at least one value",
+				filter.Op, filter.Field)
+		}
+
+		return items, nil
+	case domain.Between:
+		if !isList || len(items) != betweenBoundsLen {
+			return nil, errors.Errorf("repository: operator %d on field %s requires exactly two values",
+				filter.Op, filter.Field)
+		}
+
+		return items, nil
+	default:
+		if isList {
+			return nil, errors.Errorf("repository: operator %d on field %s requires a single value, got a list",
+				filter.Op, filter.Field)
+		}
+
+		return []any{filter.Value}, nil
+	}
+}
+
+// listItems returns the items of value when it is a slice. Byte slices are binary scalars, not lists.
+func listItems(value any) ([]any, bool) {
+	if value == nil {
+		return nil, false
+	}
+
+	rv := reflect.ValueOf(value)
+	if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() == reflect.Uint8 {
+		return nil, false
+	}
+
+	items := make([]any, rv.Len())
+
+	for idx := range rv.Len() {
+		items[idx] = rv.Index(idx).Interface()
+	}
+
+	return items, true
 }
 
 func buildFilterWildcards(op domain.Op, sensitive bool) (string, error) {
@@ -84,43 +257,59 @@ func buildFilterWildcards(op domain.Op, sensitive bool) (string, error) {
 	return wildcard, nil
 }
 
-func castFilterValue(
+// CastFilterValue converts the raw value of a filter into the Go type matching the value type. Values of IN,
+// NOT IN and BETWEEN filters are comma-separated lists.
+func CastFilterValue(
+	value string,
+	valueType domain.FilterValueType,
+	op domain.Op,
+	caseSensitive bool,
+) (any, error) {
+	return CastFilterList(value, ",", valueType, op, caseSensitive)
+}
+
+// CastFilterList is CastFilterValue for lists separated by the given separator, such as the vertical bars of
+// the IN filters of the gRPC API.
+func CastFilterList(
 	value string,
+	separator string,
 	valueType domain.FilterValueType,
 	op domain.Op,
 	caseSensitive bool,
 ) (any, error) {
 	switch valueType {
 	case domain.String:
-		return castStringValue(value, op, caseSensitive)
+		return castStringValue(value, separator, op, caseSensitive)
 	case domain.Integer:
-		return castIntegerValue(value, op)
+		return castIntegerValue(value, separator, op)
 	case domain.Float:
-		return castFloatValue(value, op)
+		return castFloatValue(value, separator, op)
 	case domain.Boolean:
-		return castBooleanValue(value, op)
+		return castBooleanValue(value, separator, op)
 	case domain.Date, domain.Time, domain.DateTime:
-		return castTimeValue(value, valueType, op)
+		return castTimeValue(value, separator, valueType, op)
+	case domain.UUID:
+		return castUUIDValue(value, separator, op)
 	default:
 		return nil, errors.Errorf("repository: unsupported filter value type: %d", valueType)
 	}
 }
 
-func castStringValue(value string, op domain.Op, caseSensitive bool) (any, error) {
+func castStringValue(value string, separator string, op domain.Op, caseSensitive bool) (any, error) {
 	if caseSensitive {
 		value = strings.ToLower(value)
 	}
 
 	if op == domain.In || op == domain.NotIn || op == domain.Between {
-		return strings.Split(value, ","), nil
+		return strings.Split(value, separator), nil
 	}
 
 	return value, nil
 }
 
-func castIntegerValue(value string, op domain.Op) (any, error) {
+func castIntegerValue(value string, separator string, op domain.Op) (any, error) {
 	if op == domain.In || op == domain.NotIn || op == domain.Between {
-		values := strings.Split(value, ",")
+		values := strings.Split(value, separator)
 
 		return convertSliceToInt(values)
 	}
@@ -128,9 +317,9 @@ func castIntegerValue(value string, op domain.Op) (any, error) {
 	return strconv.Atoi(value)
 }
 
-func castFloatValue(value string, op domain.Op) (any, error) {
+func castFloatValue(value string, separator string, op domain.Op) (any, error) {
 	if op == domain.In || op == domain.NotIn || op == domain.Between {
-		values := strings.Split(value, ",")
+		values := strings.Split(value, separator)
 
 		return convertSliceToFloat(values)
 	}
@@ -138,11 +327,11 @@ func castFloatValue(value string, op domain.Op) (any, error) {
 	return strconv.ParseFloat(value, 64)
 }
 
-func castBooleanValue(value string, op domain.Op) (any, error) {
+func castBooleanValue(value string, separator string, op domain.Op) (any, error) {
 	if op == domain.Between {
 		return nil, errors.Errorf("repository: unsupported filter operator for boolean: %d", op)
 	} else if op == domain.In || op == domain.NotIn {
-		values := strings.Split(value, ",")
+		values := strings.Split(value, separator)
 
 		return convertSliceToBool(values)
 	}
@@ -150,16 +339,45 @@ func castBooleanValue(value string, op domain.Op) (any, error) {
 	return strconv.ParseBool(value)
 }
 
-func castTimeValue(value string, valueType domain.FilterValueType, op domain.Op) (any, error) {
+func castTimeValue(value string, separator string, valueType domain.FilterValueType, op domain.Op) (any, error) {
 	if op != domain.Between {
 		return strutil.ToTime(value, layoutForType(valueType))
 	}
 
-	values := strings.SplitN(value, ",", keyValueSplitLen)
+	values := strings.SplitN(value, separator, keyValueSplitLen)
 
 	return convertSliceToDate(values, layoutForType(valueType))
 }
 
+// castUUIDValue parses UUIDs, which can only be compared for equality.
+func castUUIDValue(value string, separator string, op domain.Op) (any, error) {
+	switch op {
+	case domain.Eq, domain.Ne:
+		parsed, err := uuid.Parse(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "repository: failed to cast filter value to uuid: %s", value)
+		}
+
+		return parsed, nil
+	case domain.In, domain.NotIn:
+		values := strings.Split(value, separator)
+		parsed := make([]uuid.UUID, len(values))
+
+		for idx, val := range values {
+			id, err := uuid.Parse(val)
+			if err != nil {
+				return nil, errors.Wrapf(err, "repository: failed to cast filter value to uuid: %s", val)
+			}
+
+			parsed[idx] = id
+		}
+
+		return parsed, nil
+	default:
+		return nil, errors.Errorf("repository: unsupported filter operator for uuid: %d", op)
+	}
+}
+
 func layoutForType(valueType domain.FilterValueType) string {
 	switch valueType {
 	case domain.Date:
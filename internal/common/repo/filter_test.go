@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/vnworkday/account/internal/common/fixture"
 
 	"github.com/vnworkday/account/internal/common/domain"
@@ -144,6 +145,122 @@ func TestStringifyFilter(t *testing.T) {
 	}
 }
 
+func TestStringifyCondition(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Clause string
+		Args   []any
+	}
+
+	active := domain.Filter{Field: "status", Op: domain.Eq, Value: 1}
+	premium := domain.Filter{Field: "subscription_type", Op: domain.Eq, Value: 2}
+	vnPrefix := domain.Filter{Field: "name", Op: domain.StartsWith, Value: "VN"}
+
+	tests := []struct {
+		name      string
+		condition domain.Condition
+		optAlias  []string
+		want      result
+		wantErr   bool
+	}{
+		{
+			name:      "PlainFilter",
+			condition: active,
+			want:      result{Clause: "status = ?", Args: []any{1}},
+		},
+		{
+			name:      "FilterPointer",
+			condition: &active,
+			want:      result{Clause: "status = ?", Args: []any{1}},
+		},
+		{
+			name:      "OrWithNestedAnd",
+			condition: domain.AnyOf(active, domain.AllOf(premium, vnPrefix)),
+			want: result{
+				Clause: "(status = ? OR (subscription_type = ? AND name LIKE ? || '%'))",
+				Args:   []any{1, 2, "VN"},
+			},
+		},
+		{
+			name:      "AndWithNestedOr",
+			condition: domain.AllOf(domain.AnyOf(active, premium), vnPrefix),
+			optAlias:  []string{"t"},
+			want: result{
+				Clause: "((t.status = ? OR t.subscription_type = ?) AND t.name LIKE ? || '%')",
+				Args:   []any{1, 2, "VN"},
+			},
+		},
+		{
+			name:      "NegatedFilter",
+			condition: domain.Negate(active),
+			want:      result{Clause: "NOT (status = ?)", Args: []any{1}},
+		},
+		{
+			name:      "NegatedGroup",
+			condition: domain.Negate(domain.AnyOf(active, premium)),
+			want:      result{Clause: "NOT (status = ? OR subscription_type = ?)", Args: []any{1, 2}},
+		},
+		{
+			name: "NotGroupWithSeveralConditions",
+			condition: domain.FilterGroup{
+				Op:         domain.Not,
+				Conditions: []domain.Condition{active, premium},
+			},
+			want: result{Clause: "NOT (status = ? AND subscription_type = ?)", Args: []any{1, 2}},
+		},
+		{
+			name:      "SingleConditionGroupIsNotParenthesised",
+			condition: domain.AllOf(active),
+			want:      result{Clause: "status = ?", Args: []any{1}},
+		},
+		{
+			name: "DeeplyNestedWithLists",
+			condition: domain.AnyOf(
+				domain.Filter{Field: "status", Op: domain.In, Value: []int{1, 2}},
+				domain.AllOf(
+					domain.Negate(domain.Filter{Field: "deleted_at", Op: domain.Null}),
+					domain.Filter{Field: "created_at", Op: domain.Between, Value: []string{"2024-01-01", "2024-12-31"}},
+				),
+			),
+			want: result{
+				Clause: "(status IN (?, ?) OR (NOT (deleted_at IS NULL) AND created_at BETWEEN ? AND ?))",
+				Args:   []any{1, 2, "2024-01-01", "2024-12-31"},
+			},
+		},
+		{
+			name:      "EmptyGroup",
+			condition: domain.AnyOf(),
+			wantErr:   true,
+		},
+		{
+			name:      "InvalidGroupOperator",
+			condition: domain.FilterGroup{Op: domain.GroupOp(999), Conditions: []domain.Condition{active}},
+			wantErr:   true,
+		},
+		{
+			name:      "InvalidNestedFilter",
+			condition: domain.AllOf(active, domain.Filter{Op: domain.Eq}),
+			wantErr:   true,
+		},
+		{
+			name:      "NilCondition",
+			condition: nil,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clause, args, gotErr := stringifyCondition(tt.condition, false, tt.optAlias...)
+
+			fixture.ExpectationsWereMet(t, tt.want, result{Clause: clause, Args: args}, tt.wantErr, gotErr)
+		})
+	}
+}
+
 func TestBuildFilterWildcards(t *testing.T) {
 	t.Parallel()
 
@@ -331,19 +448,77 @@ func TestCastFilterValue(t *testing.T) {
 			want:      nil,
 			wantErr:   true,
 		},
+		{
+			name:      "UUIDValueInOperator",
+			value:     "0190b0b0-7d6c-7b3e-8f4a-2c1d9e8f7a6b,0190b0b0-7d6c-7b3e-8f4a-2c1d9e8f7a6c",
+			valueType: domain.UUID,
+			op:        domain.In,
+			want: []uuid.UUID{
+				uuid.MustParse("0190b0b0-7d6c-7b3e-8f4a-2c1d9e8f7a6b"),
+				uuid.MustParse("0190b0b0-7d6c-7b3e-8f4a-2c1d9e8f7a6c"),
+			},
+			wantErr: false,
+		},
+		{
+			name:      "InvalidUUIDFormat",
+			value:     "42",
+			valueType: domain.UUID,
+			op:        domain.Eq,
+			want:      nil,
+			wantErr:   true,
+		},
+		{
+			name:      "UnsupportedOperatorForUUID",
+			value:     "0190b0b0",
+			valueType: domain.UUID,
+			op:        domain.StartsWith,
+			want:      nil,
+			wantErr:   true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, gotErr := castFilterValue(tt.value, tt.valueType, tt.op, tt.caseSensitive)
+			got, gotErr := CastFilterValue(tt.value, tt.valueType, tt.op, tt.caseSensitive)
 
 			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
 		})
 	}
 }
 
+func TestCastFilterList(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		value     string
+		separator string
+		valueType domain.FilterValueType
+		want      any
+	}{
+		{
+			name:      "WithBars",
+			value:     "Acme|Công ty, TNHH",
+			separator: "|",
+			valueType: domain.String,
+			want:      []string{"Acme", "Công ty, TNHH"},
+		},
+		{name: "WithCommas", value: "1,2", separator: ",", valueType: domain.Integer, want: []int{1, 2}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := CastFilterList(tt.value, tt.separator, tt.valueType, domain.In, false)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, false, err)
+		})
+	}
+}
+
 func TestCastStringValue(t *testing.T) {
 	t.Parallel()
 
@@ -397,7 +572,7 @@ func TestCastStringValue(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, gotErr := castStringValue(tt.value, tt.op, tt.caseSensitive)
+			got, gotErr := castStringValue(tt.value, ",", tt.op, tt.caseSensitive)
 
 			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
 		})
@@ -594,11 +769,11 @@ func TestCastNumericValue(t *testing.T) {
 
 			switch tt.valueType {
 			case reflect.Float64:
-				got, gotErr = castFloatValue(tt.value, tt.op)
+				got, gotErr = castFloatValue(tt.value, ",", tt.op)
 			case reflect.Int:
-				got, gotErr = castIntegerValue(tt.value, tt.op)
+				got, gotErr = castIntegerValue(tt.value, ",", tt.op)
 			case reflect.Bool:
-				got, gotErr = castBooleanValue(tt.value, tt.op)
+				got, gotErr = castBooleanValue(tt.value, ",", tt.op)
 			default:
 				t.Errorf("unsupported value type: %v", tt.valueType)
 			}
@@ -676,7 +851,7 @@ func TestCastTimeValue(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			t.Parallel()
 
-			got, err := castTimeValue(tt.value, tt.valueType, tt.operator)
+			got, err := castTimeValue(tt.value, ",", tt.valueType, tt.operator)
 			if (err != nil) != tt.wantErr {
 				t.Errorf("castTimeValue() error = %v, wantErr %v", err, tt.wantErr)
 			}
@@ -0,0 +1,327 @@
+package repo
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+type InsertBuilder[T any] struct {
+	insertClause    string
+	columns         []string
+	rows            [][]any
+	batchSize       int
+	conflictClause  string
+	returningClause string
+	placeholder     PlaceholderFormat
+	err             error
+}
+
+func NewInsertBuilder[T any]() *InsertBuilder[T] {
+	return &InsertBuilder[T]{}
+}
+
+// Placeholder sets the format of the bind parameters in the built query. Defaults to Question.
+func (b *InsertBuilder[T]) Placeholder(format PlaceholderFormat) *InsertBuilder[T] {
+	b.placeholder = format
+
+	return b
+}
+
+func (b *InsertBuilder[T]) InsertInto(table string) *InsertBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	if table == "" {
+		b.err = errors.New("repository: target table is required")
+
+		return b
+	}
+
+	b.insertClause = "INSERT INTO " + table
+
+	return b
+}
+
+// Values inserts the given columns of the entity, or all of its tagged fields when no column is given.
+func (b *InsertBuilder[T]) Values(values *T, columns ...string) *InsertBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	setters, err := toInsertSetters(values)
+	if err != nil {
+		b.err = errors.Wrap(err, "repository: failed to convert values to setters")
+
+		return b
+	}
+
+	if len(columns) > 0 {
+		if setters, err = PickSetters(setters, columns...); err != nil {
+			b.err = err
+
+			return b
+		}
+	}
+
+	return b.SetterValues(setters...)
+}
+
+func (b *InsertBuilder[T]) SetterValues(values ...Setter) *InsertBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	if len(values) == 0 {
+		b.err = errors.New("repository: values are required")
+
+		return b
+	}
+
+	columns := make([]string, 0, len(values))
+	row := make([]any, 0, len(values))
+
+	for _, setter := range values {
+		if setter.Field == "" {
+			b.err = errors.New("repository: field in setter is required")
+
+			return b
+		}
+
+		columns = append(columns, setter.Field)
+		row = append(row, setter.Value)
+	}
+
+	b.columns = columns
+	b.rows = [][]any{row}
+
+	return b
+}
+
+// ValuesBatch inserts one row per entity, with the given columns or all tagged fields when no column is given.
+// The rows are sent in batches that stay under the bind parameter limit of PostgreSQL, which must run in a
+// transaction when there are several of them.
+func (b *InsertBuilder[T]) ValuesBatch(values []*T, columns ...string) *InsertBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	b.columns, b.rows, b.err = batchRows(values, columns)
+
+	return b
+}
+
+// BatchSize caps the number of rows sent per statement. Defaults to as many as the bind parameter limit allows.
+func (b *InsertBuilder[T]) BatchSize(rows int) *InsertBuilder[T] {
+	b.batchSize = rows
+
+	return b
+}
+
+// OnConflictDoNothing skips the row when it conflicts with an existing one, on the given unique columns or on
+// any constraint when none is given.
+func (b *InsertBuilder[T]) OnConflictDoNothing(target ...string) *InsertBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	b.conflictClause = " ON CONFLICT" + stringifyConflictTarget(target) + " DO NOTHING"
+
+	return b
+}
+
+// OnConflictDoUpdate turns the insert into an upsert: when the row conflicts with an existing one on the
+// target columns, the given columns of the existing row are set to the inserted values.
+func (b *InsertBuilder[T]) OnConflictDoUpdate(target []string, columns ...string) *InsertBuilder[T] {
+	setters := make([]Setter, 0, len(columns))
+
+	for _, column := range columns {
+		setters = append(setters, Setter{Field: column, Value: Expr("EXCLUDED." + column)})
+	}
+
+	return b.OnConflictDoUpdateSet(target, setters...)
+}
+
+// OnConflictDoUpdateSet is OnConflictDoUpdate with explicit assignments, whose values must be expressions. They
+// can refer to the inserted row as EXCLUDED and to the existing one by the name of the table.
+func (b *InsertBuilder[T]) OnConflictDoUpdateSet(target []string, setters ...Setter) *InsertBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	if len(target) == 0 {
+		b.err = errors.New("repository: conflict target is required to update on conflict")
+
+		return b
+	}
+
+	if len(setters) == 0 {
+		return b.OnConflictDoNothing(target...)
+	}
+
+	for _, setter := range setters {
+		if _, ok := setter.Value.(Expr); !ok {
+			b.err = errors.Errorf("repository: conflict update of column %s must be an expression", setter.Field)
+
+			return b
+		}
+	}
+
+	assignments, _, err := stringifySetters(setters)
+	if err != nil {
+		b.err = err
+
+		return b
+	}
+
+	b.conflictClause = " ON CONFLICT" + stringifyConflictTarget(target) + " DO UPDATE SET " + assignments
+
+	return b
+}
+
+func (b *InsertBuilder[T]) Returning(columns ...string) *InsertBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	b.returningClause, b.err = stringifyReturning(columns)
+
+	return b
+}
+
+// build renders the statement that inserts the given rows.
+func (b *InsertBuilder[T]) build(rows [][]any) (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	if b.insertClause == "" {
+		return "", errors.New("repository: insert clause is required")
+	}
+
+	if len(b.rows) == 0 {
+		return "", errors.New("repository: values are required")
+	}
+
+	return placeholderOrDefault(b.placeholder).Replace(fmt.Sprintf("%s (%s) VALUES %s%s%s",
+		b.insertClause,
+		strings.Join(b.columns, ", "),
+		stringifyRows(rows),
+		b.conflictClause,
+		b.returningClause,
+	))
+}
+
+// String renders the statement of the first batch.
+func (b *InsertBuilder[T]) String() (string, error) {
+	chunks := chunkRows(b.rows, len(b.columns), b.batchSize)
+	if len(chunks) == 0 {
+		return b.build(nil)
+	}
+
+	return b.build(chunks[0])
+}
+
+// Exec runs the insert and returns the number of inserted or updated rows.
+func (b *InsertBuilder[T]) Exec(ctx context.Context, db Executor) (int64, error) {
+	affected, err := b.ExecBatch(ctx, db)
+	if err != nil {
+		return -1, err
+	}
+
+	var total int64
+
+	for _, rows := range affected {
+		total += rows
+	}
+
+	return total, nil
+}
+
+// ExecBatch runs the insert one batch at a time and returns the number of inserted or updated rows of every
+// batch. Several batches must run in a UnitOfWork, which rolls back the previous ones when a batch fails.
+func (b *InsertBuilder[T]) ExecBatch(ctx context.Context, db Executor) ([]int64, error) {
+	chunks := chunkRows(b.rows, len(b.columns), b.batchSize)
+	affected := make([]int64, 0, len(chunks))
+
+	if _, err := b.build(nil); err != nil {
+		return nil, err
+	}
+
+	if err := requireTx(db, len(chunks)); err != nil {
+		return nil, err
+	}
+
+	for idx, chunk := range chunks {
+		query, err := b.build(chunk)
+		if err != nil {
+			return affected, err
+		}
+
+		rows, err := execStatement(ctx, db, query, flattenRows(chunk))
+		if err != nil {
+			return affected, errors.Wrapf(err, "repository: batch %d of %d failed", idx+1, len(chunks))
+		}
+
+		affected = append(affected, rows)
+	}
+
+	return affected, nil
+}
+
+// Query runs the insert and scans the row of its RETURNING clause. It returns sql.ErrNoRows when nothing was
+// inserted, which happens when a conflict was skipped.
+func (b *InsertBuilder[T]) Query(ctx context.Context, db Executor, scanners ...Scanner[T]) (*T, error) {
+	if len(b.rows) > 1 {
+		return nil, errors.New("repository: cannot query a single row of a batch insert")
+	}
+
+	query, err := b.build(b.rows)
+	if err != nil {
+		return nil, err
+	}
+
+	return queryOneStatement(ctx, db, query, flattenRows(b.rows), scannerOf(scanners))
+}
+
+// QueryAll runs the insert one batch at a time and scans every row of its RETURNING clause. Like ExecBatch, it
+// must run in a UnitOfWork when there are several batches.
+func (b *InsertBuilder[T]) QueryAll(ctx context.Context, db Executor, scanners ...Scanner[T]) ([]*T, error) {
+	if _, err := b.build(nil); err != nil {
+		return nil, err
+	}
+
+	chunks := chunkRows(b.rows, len(b.columns), b.batchSize)
+	if err := requireTx(db, len(chunks)); err != nil {
+		return nil, err
+	}
+
+	out := make([]*T, 0, len(b.rows))
+
+	for _, chunk := range chunks {
+		query, err := b.build(chunk)
+		if err != nil {
+			return nil, err
+		}
+
+		items, err := queryStatement(ctx, db, query, flattenRows(chunk), scannerOf(scanners))
+		if err != nil {
+			return nil, err
+		}
+
+		out = append(out, items...)
+	}
+
+	return out, nil
+}
+
+func stringifyConflictTarget(target []string) string {
+	if len(target) == 0 {
+		return ""
+	}
+
+	return " (" + strings.Join(target, ", ") + ")"
+}
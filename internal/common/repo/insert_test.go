@@ -0,0 +1,266 @@
+package repo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+type testUser struct {
+	ID        int       `db:"id"`
+	Name      string    `db:"name"`
+	Email     string    `db:"email"`
+	CreatedAt time.Time `db:"created_at"`
+	Note      string
+}
+
+func TestInsertBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	user := &testUser{ID: 1, Name: "An", Email: "an@vnworkday.com", CreatedAt: createdAt, Note: "skipped"}
+
+	type result struct {
+		Query string
+		Args  []any
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(b *InsertBuilder[testUser])
+		want    result
+		wantErr bool
+	}{
+		{
+			name: "Build With All Tagged Fields",
+			setup: func(b *InsertBuilder[testUser]) {
+				b.InsertInto("users").Values(user)
+			},
+			want: result{
+				Query: "INSERT INTO users (id, name, email, created_at) VALUES (?, ?, ?, ?)",
+				Args:  []any{1, "An", "an@vnworkday.com", createdAt},
+			},
+		},
+		{
+			name: "Build With Picked Columns And Returning",
+			setup: func(b *InsertBuilder[testUser]) {
+				b.InsertInto("users").
+					Values(user, "name", "id").
+					Returning("id", "created_at")
+			},
+			want: result{
+				Query: "INSERT INTO users (name, id) VALUES (?, ?) RETURNING id, created_at",
+				Args:  []any{"An", 1},
+			},
+		},
+		{
+			name: "Build With Conflict Do Nothing",
+			setup: func(b *InsertBuilder[testUser]) {
+				b.InsertInto("users").
+					SetterValues(Setter{Field: "email", Value: "an@vnworkday.com"}).
+					OnConflictDoNothing("email")
+			},
+			want: result{
+				Query: "INSERT INTO users (email) VALUES (?) ON CONFLICT (email) DO NOTHING",
+				Args:  []any{"an@vnworkday.com"},
+			},
+		},
+		{
+			name: "Build With Conflict On Any Constraint",
+			setup: func(b *InsertBuilder[testUser]) {
+				b.InsertInto("users").
+					SetterValues(Setter{Field: "email", Value: "an@vnworkday.com"}).
+					OnConflictDoNothing()
+			},
+			want: result{
+				Query: "INSERT INTO users (email) VALUES (?) ON CONFLICT DO NOTHING",
+				Args:  []any{"an@vnworkday.com"},
+			},
+		},
+		{
+			name: "Build Upsert With Dollar Placeholders",
+			setup: func(b *InsertBuilder[testUser]) {
+				b.Placeholder(Dollar).
+					InsertInto("users").
+					Values(user, "id", "name", "email").
+					OnConflictDoUpdate([]string{"id"}, "name", "email").
+					Returning("id", "name", "email", "created_at")
+			},
+			want: result{
+				Query: "INSERT INTO users (id, name, email) VALUES ($1, $2, $3) " +
+					"ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name, email = EXCLUDED.email " +
+					"RETURNING id, name, email, created_at",
+				Args: []any{1, "An", "an@vnworkday.com"},
+			},
+		},
+		{
+			name: "Build Upsert Without Update Columns",
+			setup: func(b *InsertBuilder[testUser]) {
+				b.InsertInto("users").
+					Values(user, "id").
+					OnConflictDoUpdate([]string{"id"})
+			},
+			want: result{
+				Query: "INSERT INTO users (id) VALUES (?) ON CONFLICT (id) DO NOTHING",
+				Args:  []any{1},
+			},
+		},
+		{
+			name: "Build Upsert Without Conflict Target",
+			setup: func(b *InsertBuilder[testUser]) {
+				b.InsertInto("users").Values(user).OnConflictDoUpdate(nil, "name")
+			},
+			wantErr: true,
+		},
+		{
+			name: "Build With Unknown Column",
+			setup: func(b *InsertBuilder[testUser]) {
+				b.InsertInto("users").Values(user, "note")
+			},
+			wantErr: true,
+		},
+		{
+			name: "Build Without Table",
+			setup: func(b *InsertBuilder[testUser]) {
+				b.Values(user)
+			},
+			wantErr: true,
+		},
+		{
+			name: "Build Without Values",
+			setup: func(b *InsertBuilder[testUser]) {
+				b.InsertInto("users")
+			},
+			wantErr: true,
+		},
+		{
+			name: "Build With Empty Setter Field",
+			setup: func(b *InsertBuilder[testUser]) {
+				b.InsertInto("users").SetterValues(Setter{Value: 1})
+			},
+			wantErr: true,
+		},
+		{
+			name: "Build With Preceding Error",
+			setup: func(b *InsertBuilder[testUser]) {
+				b.err = errors.New("forced error")
+				b.InsertInto("users").Values(user)
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := NewInsertBuilder[testUser]()
+			tt.setup(builder)
+
+			query, gotErr := builder.String()
+
+			fixture.ExpectationsWereMet(t, tt.want, result{Query: query, Args: flattenRows(builder.rows)}, tt.wantErr, gotErr)
+		})
+	}
+}
+
+type testProfile struct {
+	ID        int               `db:"id,pk"`
+	Settings  map[string]string `db:"settings,jsonb"`
+	Status    int               `db:"status,omitempty"`
+	Version   int               `db:"version,version,default=1"`
+	CreatedAt time.Time         `db:"created_at,default=now()"`
+	Cache     string            `db:"-"`
+}
+
+func TestInsertBuilder_TagOptions(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	type result struct {
+		Query string
+		Args  []any
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(b *InsertBuilder[testProfile])
+		want    result
+		wantErr bool
+	}{
+		{
+			name: "Build With Defaults For Zero Fields",
+			setup: func(b *InsertBuilder[testProfile]) {
+				b.InsertInto("profiles").Values(&testProfile{ID: 1, Settings: map[string]string{"lang": "vi"}})
+			},
+			want: result{
+				Query: "INSERT INTO profiles (id, settings, status, version, created_at) " +
+					"VALUES (?, ?, DEFAULT, 1, now())",
+				Args: []any{1, `{"lang":"vi"}`},
+			},
+		},
+		{
+			name: "Build With Set Fields",
+			setup: func(b *InsertBuilder[testProfile]) {
+				b.InsertInto("profiles").
+					Values(&testProfile{ID: 1, Status: 2, Version: 3, CreatedAt: createdAt, Cache: "skipped"})
+			},
+			want: result{
+				Query: "INSERT INTO profiles (id, settings, status, version, created_at) VALUES (?, ?, ?, ?, ?)",
+				Args:  []any{1, nil, 2, 3, createdAt},
+			},
+		},
+		{
+			name: "Build With Defaults In Batch",
+			setup: func(b *InsertBuilder[testProfile]) {
+				b.InsertInto("profiles").
+					ValuesBatch([]*testProfile{{ID: 1}, {ID: 2, Status: 2}}, "id", "status")
+			},
+			want: result{
+				Query: "INSERT INTO profiles (id, status) VALUES (?, DEFAULT), (?, ?)",
+				Args:  []any{1, 2, 2},
+			},
+		},
+		{
+			name: "Build With Conflict Update Expressions",
+			setup: func(b *InsertBuilder[testProfile]) {
+				b.InsertInto("profiles").
+					Values(&testProfile{ID: 1, Status: 2}, "id", "status").
+					OnConflictDoUpdateSet([]string{"id"},
+						Setter{Field: "status", Value: Expr("EXCLUDED.status")},
+						Setter{Field: "version", Value: Expr("profiles.version + 1")},
+					)
+			},
+			want: result{
+				Query: "INSERT INTO profiles (id, status) VALUES (?, ?) ON CONFLICT (id) DO UPDATE SET " +
+					"status = EXCLUDED.status, version = profiles.version + 1",
+				Args: []any{1, 2},
+			},
+		},
+		{
+			name: "Build With Conflict Update Value",
+			setup: func(b *InsertBuilder[testProfile]) {
+				b.InsertInto("profiles").
+					Values(&testProfile{ID: 1}, "id").
+					OnConflictDoUpdateSet([]string{"id"}, Setter{Field: "status", Value: 2})
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := NewInsertBuilder[testProfile]()
+			tt.setup(builder)
+
+			query, gotErr := builder.String()
+
+			fixture.ExpectationsWereMet(t, tt.want, result{Query: query, Args: flattenRows(builder.rows)}, tt.wantErr, gotErr)
+		})
+	}
+}
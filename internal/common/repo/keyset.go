@@ -0,0 +1,114 @@
+package repo
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+)
+
+// StringifyKeyset renders the predicate that selects the rows coming after the cursor in the given sort order,
+// or before it for a backward cursor. When all sorts share the same order it is a single row comparison such
+// as "(created_at, id) > (?, ?)", which PostgreSQL can answer from a matching index; mixed orders expand into
+// "(a > ?) OR (a = ? AND b < ?)".
+func StringifyKeyset(cursor domain.Cursor, sorts []domain.Sort, optAlias ...string) (string, []any, error) {
+	var alias string
+
+	if len(optAlias) > 0 {
+		alias = optAlias[0]
+	}
+
+	if len(sorts) == 0 {
+		return "", nil, errors.New("repository: keyset requires at least one sort")
+	}
+
+	if len(cursor.Values) != len(sorts) {
+		return "", nil, errors.Errorf("repository: keyset has %d values for %d sorts", len(cursor.Values), len(sorts))
+	}
+
+	fields := make([]string, 0, len(sorts))
+	markers := make([]string, 0, len(sorts))
+	comparators := make([]string, 0, len(sorts))
+
+	for _, sort := range sorts {
+		field, err := stringifyField(sort.Field, sort.CaseSensitive, alias)
+		if err != nil {
+			return "", nil, errors.Wrap(err, "repository: failed to stringify keyset field")
+		}
+
+		marker, _ := stringifyField(string(placeholder), sort.CaseSensitive, "")
+
+		comparator, err := keysetComparator(sort.Order, cursor.Backward)
+		if err != nil {
+			return "", nil, err
+		}
+
+		fields = append(fields, field)
+		markers = append(markers, marker)
+		comparators = append(comparators, comparator)
+	}
+
+	if sameOrder(sorts) {
+		return fmt.Sprintf("(%s) %s (%s)", strings.Join(fields, ", "), comparators[0], strings.Join(markers, ", ")),
+			cursor.Values, nil
+	}
+
+	branches := make([]string, 0, len(sorts))
+	args := make([]any, 0, len(sorts)*(len(sorts)+1)/2)
+
+	for idx := range sorts {
+		terms := make([]string, 0, idx+1)
+
+		for prev := range idx {
+			terms = append(terms, fmt.Sprintf("%s = %s", fields[prev], markers[prev]))
+			args = append(args, cursor.Values[prev])
+		}
+
+		terms = append(terms, fmt.Sprintf("%s %s %s", fields[idx], comparators[idx], markers[idx]))
+		args = append(args, cursor.Values[idx])
+
+		branches = append(branches, "("+strings.Join(terms, " AND ")+")")
+	}
+
+	return "(" + strings.Join(branches, " OR ") + ")", args, nil
+}
+
+// ReverseSorts flips the order of every sort, which is how a backward page is read before being put back in
+// the requested order.
+func ReverseSorts(sorts []domain.Sort) []domain.Sort {
+	reversed := make([]domain.Sort, 0, len(sorts))
+
+	for _, sort := range sorts {
+		if sort.Order == domain.Desc {
+			sort.Order = domain.Asc
+		} else {
+			sort.Order = domain.Desc
+		}
+
+		reversed = append(reversed, sort)
+	}
+
+	return reversed
+}
+
+func keysetComparator(order domain.SortOrder, backward bool) (string, error) {
+	switch {
+	case order == domain.Asc && !backward, order == domain.Desc && backward:
+		return ">", nil
+	case order == domain.Desc && !backward, order == domain.Asc && backward:
+		return "<", nil
+	default:
+		return "", errors.New("repository: invalid sort order " + string(order))
+	}
+}
+
+func sameOrder(sorts []domain.Sort) bool {
+	for _, sort := range sorts[1:] {
+		if sort.Order != sorts[0].Order {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,116 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+func TestStringifyKeyset(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Clause string
+		Args   []any
+	}
+
+	ascending := []domain.Sort{
+		{Field: "created_at", Order: domain.Asc},
+		{Field: "id", Order: domain.Asc},
+	}
+
+	tests := []struct {
+		name     string
+		cursor   domain.Cursor
+		sorts    []domain.Sort
+		optAlias []string
+		want     result
+		wantErr  bool
+	}{
+		{
+			name:   "ForwardSameOrder",
+			cursor: domain.Cursor{Values: []any{"2024-01-01", 7}},
+			sorts:  ascending,
+			want: result{
+				Clause: "(created_at, id) > (?, ?)",
+				Args:   []any{"2024-01-01", 7},
+			},
+		},
+		{
+			name:     "BackwardSameOrderWithAlias",
+			cursor:   domain.Cursor{Values: []any{"2024-01-01", 7}, Backward: true},
+			sorts:    ascending,
+			optAlias: []string{"t"},
+			want: result{
+				Clause: "(t.created_at, t.id) < (?, ?)",
+				Args:   []any{"2024-01-01", 7},
+			},
+		},
+		{
+			name:   "ForwardDescending",
+			cursor: domain.Cursor{Values: []any{"acme"}},
+			sorts:  []domain.Sort{{Field: "name", Order: domain.Desc}},
+			want: result{
+				Clause: "(name) < (?)",
+				Args:   []any{"acme"},
+			},
+		},
+		{
+			name:   "MixedOrders",
+			cursor: domain.Cursor{Values: []any{"acme", "2024-01-01", 7}},
+			sorts: []domain.Sort{
+				{Field: "name", Order: domain.Desc, CaseSensitive: true},
+				{Field: "created_at", Order: domain.Asc},
+				{Field: "id", Order: domain.Asc},
+			},
+			want: result{
+				Clause: "((LOWER(name) < LOWER(?)) OR (LOWER(name) = LOWER(?) AND created_at > ?) OR " +
+					"(LOWER(name) = LOWER(?) AND created_at = ? AND id > ?))",
+				Args: []any{"acme", "acme", "2024-01-01", "acme", "2024-01-01", 7},
+			},
+		},
+		{
+			name:    "ValueCountMismatch",
+			cursor:  domain.Cursor{Values: []any{"2024-01-01"}},
+			sorts:   ascending,
+			wantErr: true,
+		},
+		{
+			name:    "NoSorts",
+			cursor:  domain.Cursor{},
+			wantErr: true,
+		},
+		{
+			name:    "InvalidOrder",
+			cursor:  domain.Cursor{Values: []any{1}},
+			sorts:   []domain.Sort{{Field: "id", Order: "up"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			clause, args, gotErr := StringifyKeyset(tt.cursor, tt.sorts, tt.optAlias...)
+
+			fixture.ExpectationsWereMet(t, tt.want, result{Clause: clause, Args: args}, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestReverseSorts(t *testing.T) {
+	t.Parallel()
+
+	input := []domain.Sort{
+		{Field: "name", Order: domain.Desc, CaseSensitive: true},
+		{Field: "id", Order: domain.Asc},
+	}
+	want := []domain.Sort{
+		{Field: "name", Order: domain.Asc, CaseSensitive: true},
+		{Field: "id", Order: domain.Desc},
+	}
+
+	fixture.ExpectationsWereMet(t, want, ReverseSorts(input), false, nil)
+}
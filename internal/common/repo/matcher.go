@@ -2,17 +2,18 @@ package repo
 
 import (
 	"context"
-	"database/sql"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"slices"
 	"strings"
 
 	"github.com/gookit/goutil/arrutil"
 	"github.com/gookit/goutil/reflects"
-	"github.com/gookit/goutil/structs"
 	"github.com/gookit/goutil/strutil"
 
 	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
 )
 
 type MatcherBuilder[T any] struct {
@@ -26,21 +27,106 @@ type Setter struct {
 	Value any
 }
 
+// Expr is a SQL expression, such as DEFAULT or "version + 1", that the builders render verbatim in place of a
+// bind parameter when it is the value of a setter or of an inserted row.
+type Expr string
+
+// ToSetters maps the fields of a struct to setters named after the columns of their db tags, in the order of
+// the fields. Fields without a db tag or tagged "-" are skipped, and fields tagged json or jsonb hold their JSON
+// encoding.
 func ToSetters[T any](in T) ([]Setter, error) {
-	temp, err := structs.StructToMap(in)
-	if err != nil {
-		return nil, err
+	return toSetters(in, false)
+}
+
+// toInsertSetters is ToSetters for inserts: the zero fields tagged omitempty or default=expr hold DEFAULT or
+// their expression, so that the database fills them.
+func toInsertSetters[T any](in T) ([]Setter, error) {
+	return toSetters(in, true)
+}
+
+func toSetters(in any, insert bool) ([]Setter, error) {
+	value := reflect.Indirect(reflect.ValueOf(in))
+
+	if value.Kind() != reflect.Struct {
+		return nil, errors.Errorf("repository: cannot convert %s to setters", value.Kind())
 	}
 
-	setters := make([]Setter, 0, len(temp))
+	setters := make([]Setter, 0, value.NumField())
+
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
 
-	for key, value := range temp {
-		setters = append(setters, Setter{Field: key, Value: value})
+		tag := field.Tag.Get("db")
+		if tag == "" || tag == "-" {
+			continue
+		}
+
+		column, err := domain.ParseTag(tag, field.Name)
+		if err != nil {
+			return nil, err
+		}
+
+		setter, err := columnSetter(column, value.Field(i), insert)
+		if err != nil {
+			return nil, err
+		}
+
+		setters = append(setters, setter)
 	}
 
 	return setters, nil
 }
 
+func columnSetter(column domain.Column, field reflect.Value, insert bool) (Setter, error) {
+	switch {
+	case insert && column.Default != "" && field.IsZero():
+		return Setter{Field: column.Name, Value: Expr(column.Default)}, nil
+	case insert && column.OmitEmpty && field.IsZero():
+		return Setter{Field: column.Name, Value: Expr("DEFAULT")}, nil
+	case column.JSON:
+		if isNil(field) {
+			return Setter{Field: column.Name, Value: nil}, nil
+		}
+
+		data, err := json.Marshal(field.Interface())
+		if err != nil {
+			return Setter{}, errors.Wrapf(err, "repository: cannot encode column %s as json", column.Name)
+		}
+
+		return Setter{Field: column.Name, Value: string(data)}, nil
+	default:
+		return Setter{Field: column.Name, Value: field.Interface()}, nil
+	}
+}
+
+func isNil(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Interface:
+		return value.IsNil()
+	default:
+		return false
+	}
+}
+
+// PickSetters returns the setters of the given columns, in the order of the columns.
+func PickSetters(setters []Setter, columns ...string) ([]Setter, error) {
+	picked := make([]Setter, 0, len(columns))
+
+	for _, column := range columns {
+		idx := slices.IndexFunc(setters, func(setter Setter) bool {
+			return setter.Field == column
+		})
+
+		if idx < 0 {
+			return nil, errors.Errorf("repository: no value for column %s", column)
+		}
+
+		picked = append(picked, setters[idx])
+	}
+
+	return picked, nil
+}
+
 func NewErrorMatcher[T any](matched bool, err error) *MatcherBuilder[T] {
 	return &MatcherBuilder[T]{
 		err:     err,
@@ -208,7 +294,7 @@ func (m *MatcherBuilder[T]) thenInsert(setters ...Setter) *MutationBuilder[T] {
 	return m.mb
 }
 
-func (m *MatcherBuilder[T]) Exec(ctx context.Context, db *sql.DB) (int64, error) {
+func (m *MatcherBuilder[T]) Exec(ctx context.Context, db Executor) (int64, error) {
 	if m.err != nil {
 		return -1, m.err
 	}
@@ -3,6 +3,7 @@ package repo
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/vnworkday/account/internal/common/fixture"
 
@@ -287,3 +288,56 @@ func TestMatcherBuilder_ThenUpdateOrInsert(t *testing.T) {
 		})
 	}
 }
+
+func TestToSetters(t *testing.T) {
+	t.Parallel()
+
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		input   any
+		want    []Setter
+		wantErr bool
+	}{
+		{
+			name:  "ToSetters Uses Db Tags In Field Order",
+			input: &testUser{ID: 1, Name: "An", Email: "an@vnworkday.com", CreatedAt: createdAt, Note: "skipped"},
+			want: []Setter{
+				{Field: "id", Value: 1},
+				{Field: "name", Value: "An"},
+				{Field: "email", Value: "an@vnworkday.com"},
+				{Field: "created_at", Value: createdAt},
+			},
+			wantErr: false,
+		},
+		{
+			name:  "ToSetters Encodes Json And Skips Dash",
+			input: &testProfile{ID: 1, Settings: map[string]string{"lang": "vi"}, Cache: "skipped"},
+			want: []Setter{
+				{Field: "id", Value: 1},
+				{Field: "settings", Value: `{"lang":"vi"}`},
+				{Field: "status", Value: 0},
+				{Field: "version", Value: 0},
+				{Field: "created_at", Value: time.Time{}},
+			},
+			wantErr: false,
+		},
+		{
+			name:    "ToSetters With Non Struct",
+			input:   42,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotErr := ToSetters(tt.input)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
@@ -19,5 +19,6 @@ func Register() fx.Option {
 				return conn.Close()
 			}),
 		),
+		ioc.RegisterWithName(NewUnitOfWork),
 	)
 }
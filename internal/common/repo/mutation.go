@@ -38,6 +38,7 @@ type MutationBuilder[T any] struct {
 	onClause       strings.Builder
 	notMatchClause strings.Builder
 	matchClause    strings.Builder
+	placeholder    PlaceholderFormat
 	err            error
 }
 
@@ -45,6 +46,13 @@ func NewMutationBuilder[T any]() *MutationBuilder[T] {
 	return &MutationBuilder[T]{}
 }
 
+// Placeholder sets the format of the bind parameters in the built query. Defaults to Question.
+func (b *MutationBuilder[T]) Placeholder(format PlaceholderFormat) *MutationBuilder[T] {
+	b.placeholder = format
+
+	return b
+}
+
 func (b *MutationBuilder[T]) MergeInto(table string) *MutationBuilder[T] {
 	if b.err != nil {
 		return b
@@ -153,6 +161,25 @@ func (b *MutationBuilder[T]) On(mergeCond MergeCondition) *MutationBuilder[T] {
 	return b.onRaw(raw)
 }
 
+// OnPrimaryKey matches the source and target rows on every column of the primary key of the table.
+func (b *MutationBuilder[T]) OnPrimaryKey(table *domain.Table) *MutationBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	if len(table.PrimaryKey) == 0 {
+		b.err = errors.Errorf("repository: table %s has no column tagged pk", table.Name)
+
+		return b
+	}
+
+	for _, column := range table.PrimaryKey {
+		b = b.On(MergeCondition{SourceCol: column, TargetCol: column, Op: domain.Eq})
+	}
+
+	return b
+}
+
 func (b *MutationBuilder[T]) onRaw(mergeCond string) *MutationBuilder[T] {
 	if b.err != nil {
 		return b
@@ -240,7 +267,7 @@ func (b *MutationBuilder[T]) build() (string, error) {
 		query += " " + b.matchClause.String()
 	}
 
-	return strings.TrimSpace(query), nil
+	return placeholderOrDefault(b.placeholder).Replace(strings.TrimSpace(query))
 }
 
 func (b *MutationBuilder[T]) reset() {
@@ -255,7 +282,8 @@ func (b *MutationBuilder[T]) reset() {
 	b.err = nil
 }
 
-func (b *MutationBuilder[T]) Exec(ctx context.Context, db *sql.DB) (int64, error) {
+// Exec runs the merge and returns the number of affected rows.
+func (b *MutationBuilder[T]) Exec(ctx context.Context, db Executor) (int64, error) {
 	var out sql.Result
 	var query string
 	var err error
@@ -237,6 +237,40 @@ func TestMutationBuilder_On(t *testing.T) {
 	}
 }
 
+func TestMutationBuilder_OnPrimaryKey(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		table   *domain.Table
+		want    string
+		wantErr bool
+	}{
+		{
+			name:    "OnPrimaryKey With Composite Key",
+			table:   &domain.Table{Name: "membership", PrimaryKey: []string{"tenant_id", "user_id"}},
+			want:    "ON source.tenant_id = target.tenant_id AND source.user_id = target.user_id",
+			wantErr: false,
+		},
+		{
+			name:    "OnPrimaryKey Without Key",
+			table:   &domain.Table{Name: "membership"},
+			want:    "",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotBuilder := NewMutationBuilder[string]().OnPrimaryKey(tt.table)
+
+			fixture.ExpectationsWereMet(t, tt.want, gotBuilder.onClause.String(), tt.wantErr, gotBuilder.err)
+		})
+	}
+}
+
 func TestMutationBuilder_OnRaw(t *testing.T) {
 	t.Parallel()
 
@@ -566,6 +600,29 @@ func TestMutationBuilder_Build(t *testing.T) {
 				"WHEN MATCHED AND target.name = source.name THEN DO NOTHING",
 			wantErr: false,
 		},
+		{
+			name: "Build With Dollar Placeholders",
+			setup: func(b *MutationBuilder[string]) {
+				b.Placeholder(Dollar).
+					MergeInto("users").
+					UsingValues(Setter{Field: "id", Value: 1}, Setter{Field: "name", Value: "John Doe"}).
+					On(MergeCondition{
+						SourceCol: "id",
+						TargetCol: "id",
+						Op:        domain.Eq,
+					}).
+					WhenMatched().
+					ThenUpdate("name").
+					WhenNotMatched().
+					ThenInsert("id", "name")
+			},
+			want: "MERGE INTO users AS target " +
+				"USING (VALUES ($1, $2)) AS source (id, name) " +
+				"ON source.id = target.id " +
+				"WHEN NOT MATCHED THEN INSERT (id, name) VALUES (source.id, source.name) " +
+				"WHEN MATCHED THEN UPDATE SET name = source.name",
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
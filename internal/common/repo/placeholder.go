@@ -0,0 +1,92 @@
+package repo
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const placeholder = '?'
+
+// PlaceholderFormat rewrites the generic "?" bind parameters of a built query into the markers of a SQL dialect.
+type PlaceholderFormat interface {
+	Replace(query string) (string, error)
+}
+
+var (
+	// Question keeps the "?" markers as they are, as expected by MySQL or SQLite drivers, and only turns the
+	// escaped "??" into "?".
+	Question PlaceholderFormat = questionFormat{}
+	// Dollar numbers the markers as $1, $2, ... in order of appearance, as expected by PostgreSQL drivers.
+	Dollar PlaceholderFormat = dollarFormat{}
+)
+
+type questionFormat struct{}
+
+func (questionFormat) Replace(query string) (string, error) {
+	return replacePlaceholders(query, func(sb *strings.Builder, _ int) {
+		sb.WriteByte(placeholder)
+	})
+}
+
+type dollarFormat struct{}
+
+func (dollarFormat) Replace(query string) (string, error) {
+	return replacePlaceholders(query, func(sb *strings.Builder, position int) {
+		sb.WriteByte('$')
+		sb.WriteString(strconv.Itoa(position))
+	})
+}
+
+// replacePlaceholders calls write for every "?" found outside quoted literals and identifiers,
+// numbering them from 1. A doubled "??" is an escaped question mark and is written as a single "?".
+func replacePlaceholders(query string, write func(sb *strings.Builder, position int)) (string, error) {
+	var sb strings.Builder
+
+	sb.Grow(len(query))
+
+	position := 0
+	quote := byte(0)
+
+	for idx := 0; idx < len(query); idx++ {
+		char := query[idx]
+
+		switch {
+		case quote != 0:
+			if char == quote {
+				quote = 0
+			}
+
+			sb.WriteByte(char)
+		case char == '\'' || char == '"':
+			quote = char
+
+			sb.WriteByte(char)
+		case char == placeholder && idx+1 < len(query) && query[idx+1] == placeholder:
+			sb.WriteByte(placeholder)
+
+			idx++
+		case char == placeholder:
+			position++
+
+			write(&sb, position)
+		default:
+			sb.WriteByte(char)
+		}
+	}
+
+	if quote != 0 {
+		return "", errors.Errorf("repository: unterminated quoted string in query: %s", query)
+	}
+
+	return sb.String(), nil
+}
+
+func placeholderOrDefault(format PlaceholderFormat) PlaceholderFormat {
+	if format == nil {
+		return Question
+	}
+
+	return format
+}
@@ -0,0 +1,84 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+func TestPlaceholderFormat_Replace(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		format  PlaceholderFormat
+		query   string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:   "QuestionKeepsMarkers",
+			format: Question,
+			query:  "SELECT id FROM users WHERE name = ? AND age > ?",
+			want:   "SELECT id FROM users WHERE name = ? AND age > ?",
+		},
+		{
+			name:   "QuestionUnescapesDoubledMarkers",
+			format: Question,
+			query:  "SELECT data ?? 'key' FROM users WHERE id = ?",
+			want:   "SELECT data ? 'key' FROM users WHERE id = ?",
+		},
+		{
+			name:    "QuestionWithUnterminatedLiteral",
+			format:  Question,
+			query:   "SELECT id FROM users WHERE name = 'John AND age = ?",
+			wantErr: true,
+		},
+		{
+			name:   "DollarNumbersMarkersInOrder",
+			format: Dollar,
+			query:  "SELECT id FROM users WHERE name = ? AND age BETWEEN ? AND ?",
+			want:   "SELECT id FROM users WHERE name = $1 AND age BETWEEN $2 AND $3",
+		},
+		{
+			name:   "DollarWithoutMarkers",
+			format: Dollar,
+			query:  "SELECT id FROM users",
+			want:   "SELECT id FROM users",
+		},
+		{
+			name:   "DollarSkipsQuotedLiterals",
+			format: Dollar,
+			query:  "SELECT '?' AS q, \"what?\" FROM users WHERE name LIKE '%' || ? || '%'",
+			want:   "SELECT '?' AS q, \"what?\" FROM users WHERE name LIKE '%' || $1 || '%'",
+		},
+		{
+			name:   "DollarSkipsEscapedQuotes",
+			format: Dollar,
+			query:  "SELECT id FROM users WHERE name = 'O''Reilly?' AND age = ?",
+			want:   "SELECT id FROM users WHERE name = 'O''Reilly?' AND age = $1",
+		},
+		{
+			name:   "DollarUnescapesDoubledMarkers",
+			format: Dollar,
+			query:  "SELECT data ?? 'key' FROM users WHERE id = ?",
+			want:   "SELECT data ? 'key' FROM users WHERE id = $1",
+		},
+		{
+			name:    "DollarWithUnterminatedLiteral",
+			format:  Dollar,
+			query:   "SELECT id FROM users WHERE name = 'John AND age = ?",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, gotErr := tt.format.Replace(tt.query)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
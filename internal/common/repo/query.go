@@ -19,6 +19,8 @@ type QueryBuilder[T any] struct {
 	whereClause      strings.Builder
 	whereArgs        []any
 	sortClause       strings.Builder
+	placeholder      PlaceholderFormat
+	bindArrays       bool
 	err              error
 }
 
@@ -26,6 +28,21 @@ func NewQueryBuilder[T any]() *QueryBuilder[T] {
 	return &QueryBuilder[T]{}
 }
 
+// Placeholder sets the format of the bind parameters in the built query. Defaults to Question.
+func (b *QueryBuilder[T]) Placeholder(format PlaceholderFormat) *QueryBuilder[T] {
+	b.placeholder = format
+
+	return b
+}
+
+// BindArrays makes the next IN and NOT IN filters bind their list as a single array parameter, rendered as
+// "= ANY(?)" and "<> ALL(?)", instead of one parameter per item. Only supported by PostgreSQL.
+func (b *QueryBuilder[T]) BindArrays() *QueryBuilder[T] {
+	b.bindArrays = true
+
+	return b
+}
+
 func (b *QueryBuilder[T]) Select(fields ...string) *QueryBuilder[T] {
 	if b.err != nil {
 		return b
@@ -96,12 +113,13 @@ func (b *QueryBuilder[T]) WhereRaw(where string) *QueryBuilder[T] {
 	return b
 }
 
-func (b *QueryBuilder[T]) Where(filter domain.Filter, optAlias ...string) *QueryBuilder[T] {
+// Where adds a filter or a filter group to the WHERE clause, combined with the previous ones with AND.
+func (b *QueryBuilder[T]) Where(condition domain.Condition, optAlias ...string) *QueryBuilder[T] {
 	if b.err != nil {
 		return b
 	}
 
-	whereClause, err := StringifyFilter(filter, optAlias...)
+	whereClause, args, err := stringifyCondition(condition, b.bindArrays, optAlias...)
 	if err != nil {
 		b.err = err
 
@@ -115,7 +133,27 @@ func (b *QueryBuilder[T]) Where(filter domain.Filter, optAlias ...string) *Query
 	}
 
 	b.whereClause.WriteString(whereClause)
-	b.whereArgs = append(b.whereArgs, filter.Value)
+	b.whereArgs = append(b.whereArgs, args...)
+
+	return b
+}
+
+// Keyset restricts the query to the rows that come after the cursor in the order of the sorts, or before it for
+// a backward cursor. The query must be ordered by the same sorts, reversed for a backward cursor.
+func (b *QueryBuilder[T]) Keyset(cursor domain.Cursor, sorts []domain.Sort, optAlias ...string) *QueryBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	keysetClause, args, err := StringifyKeyset(cursor, sorts, optAlias...)
+	if err != nil {
+		b.err = err
+
+		return b
+	}
+
+	b.WhereRaw(keysetClause)
+	b.whereArgs = append(b.whereArgs, args...)
 
 	return b
 }
@@ -194,11 +232,16 @@ func (b *QueryBuilder[T]) build() (string, error) {
 		return "", errors.New("repository: from clause is required")
 	}
 
-	b.query = b.selectClause +
+	query, err := placeholderOrDefault(b.placeholder).Replace(b.selectClause +
 		b.fromClause +
 		b.whereClause.String() +
 		b.sortClause.String() +
-		b.paginationClause
+		b.paginationClause)
+	if err != nil {
+		return "", err
+	}
+
+	b.query = query
 
 	return b.query, nil
 }
@@ -217,7 +260,7 @@ func (b *QueryBuilder[T]) Close() {
 	b.err = nil
 }
 
-func (b *QueryBuilder[T]) Exist(ctx context.Context, db *sql.DB) (bool, error) {
+func (b *QueryBuilder[T]) Exist(ctx context.Context, db Executor) (bool, error) {
 	var rows *sql.Rows
 	var query string
 	var err error
@@ -249,7 +292,7 @@ func (b *QueryBuilder[T]) Exist(ctx context.Context, db *sql.DB) (bool, error) {
 	return rows.Next(), nil
 }
 
-func (b *QueryBuilder[T]) Count(ctx context.Context, db *sql.DB) (int64, error) {
+func (b *QueryBuilder[T]) Count(ctx context.Context, db Executor) (int64, error) {
 	var rows *sql.Rows
 	var query string
 	var err error
@@ -289,7 +332,7 @@ func (b *QueryBuilder[T]) Count(ctx context.Context, db *sql.DB) (int64, error)
 	return count, nil
 }
 
-func (b *QueryBuilder[T]) Query(ctx context.Context, db *sql.DB, scanner func(row *sql.Rows, out T) error) (*T, error) {
+func (b *QueryBuilder[T]) Query(ctx context.Context, db Executor, scanners ...Scanner[T]) (*T, error) {
 	var out T
 	var rows *sql.Rows
 	var query string
@@ -315,8 +358,10 @@ func (b *QueryBuilder[T]) Query(ctx context.Context, db *sql.DB, scanner func(ro
 		_ = rows.Close()
 	}()
 
+	scan := scannerOf(scanners)
+
 	for rows.Next() {
-		if e := scanner(rows, out); e != nil {
+		if e := scan(rows, &out); e != nil {
 			return nil, e
 		}
 	}
@@ -330,8 +375,8 @@ func (b *QueryBuilder[T]) Query(ctx context.Context, db *sql.DB, scanner func(ro
 
 func (b *QueryBuilder[T]) QueryAll(
 	ctx context.Context,
-	db *sql.DB,
-	scanner func(row *sql.Rows, out T) error,
+	db Executor,
+	scanners ...Scanner[T],
 ) ([]*T, error) {
 	var out []*T
 	var rows *sql.Rows
@@ -358,10 +403,12 @@ func (b *QueryBuilder[T]) QueryAll(
 		_ = rows.Close()
 	}()
 
+	scan := scannerOf(scanners)
+
 	for rows.Next() {
 		var item T
 
-		if e := scanner(rows, item); e != nil {
+		if e := scan(rows, &item); e != nil {
 			return nil, e
 		}
 
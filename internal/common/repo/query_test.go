@@ -5,6 +5,8 @@ import (
 
 	"github.com/vnworkday/account/internal/common/domain"
 	"github.com/vnworkday/account/internal/common/fixture"
+
+	"github.com/lib/pq"
 )
 
 func TestQueryBuilder_Select(t *testing.T) {
@@ -164,7 +166,7 @@ func TestQueryBuilder_Where(t *testing.T) {
 			filter: domain.Filter{
 				Field: "department", Op: domain.In, Value: []string{"HR", "Engineering", "Marketing"},
 			},
-			want:    " WHERE department IN (?)",
+			want:    " WHERE department IN (?, ?, ?)",
 			wantErr: false,
 		},
 	}
@@ -183,6 +185,108 @@ func TestQueryBuilder_Where(t *testing.T) {
 	}
 }
 
+func TestQueryBuilder_WhereArgs(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Clause string
+		Args   []any
+	}
+
+	tests := []struct {
+		name       string
+		filter     domain.Filter
+		bindArrays bool
+		want       result
+		wantErr    bool
+	}{
+		{
+			name:   "ScalarValue",
+			filter: domain.Filter{Field: "name", Op: domain.Eq, Value: "John"},
+			want:   result{Clause: " WHERE name = ?", Args: []any{"John"}},
+		},
+		{
+			name:   "InExpandsSlice",
+			filter: domain.Filter{Field: "status", Op: domain.In, Value: []int{1, 2, 3}},
+			want:   result{Clause: " WHERE status IN (?, ?, ?)", Args: []any{1, 2, 3}},
+		},
+		{
+			name:   "NotInWithSingleValue",
+			filter: domain.Filter{Field: "status", Op: domain.NotIn, Value: 4},
+			want:   result{Clause: " WHERE status NOT IN (?)", Args: []any{4}},
+		},
+		{
+			name:       "InBindsArray",
+			filter:     domain.Filter{Field: "status", Op: domain.In, Value: []string{"active", "pending"}},
+			bindArrays: true,
+			want:       result{Clause: " WHERE status = ANY(?)", Args: []any{pq.Array([]any{"active", "pending"})}},
+		},
+		{
+			name:       "NotInBindsArray",
+			filter:     domain.Filter{Field: "status", Op: domain.NotIn, Value: []string{"deleted"}},
+			bindArrays: true,
+			want:       result{Clause: " WHERE status <> ALL(?)", Args: []any{pq.Array([]any{"deleted"})}},
+		},
+		{
+			name:   "BetweenBindsTwoBounds",
+			filter: domain.Filter{Field: "age", Op: domain.Between, Value: []int{18, 30}},
+			want:   result{Clause: " WHERE age BETWEEN ? AND ?", Args: []any{18, 30}},
+		},
+		{
+			name:   "NullBindsNothing",
+			filter: domain.Filter{Field: "deleted_at", Op: domain.Null},
+			want:   result{Clause: " WHERE deleted_at IS NULL"},
+		},
+		{
+			name:   "ByteSliceIsScalar",
+			filter: domain.Filter{Field: "hash", Op: domain.Eq, Value: []byte("abc")},
+			want:   result{Clause: " WHERE hash = ?", Args: []any{[]byte("abc")}},
+		},
+		{
+			name:    "InWithEmptySlice",
+			filter:  domain.Filter{Field: "status", Op: domain.In, Value: []int{}},
+			wantErr: true,
+		},
+		{
+			name:    "BetweenWithOneBound",
+			filter:  domain.Filter{Field: "age", Op: domain.Between, Value: []int{18}},
+			wantErr: true,
+		},
+		{
+			name:    "BetweenWithScalar",
+			filter:  domain.Filter{Field: "age", Op: domain.Between, Value: 18},
+			wantErr: true,
+		},
+		{
+			name:    "NotNullWithValue",
+			filter:  domain.Filter{Field: "deleted_at", Op: domain.NotNull, Value: "now"},
+			wantErr: true,
+		},
+		{
+			name:    "EqWithSlice",
+			filter:  domain.Filter{Field: "name", Op: domain.Eq, Value: []string{"a", "b"}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			qb := &QueryBuilder[any]{}
+			if tt.bindArrays {
+				qb.BindArrays()
+			}
+
+			qb.Where(tt.filter)
+
+			got := result{Clause: qb.whereClause.String(), Args: qb.whereArgs}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, qb.err)
+		})
+	}
+}
+
 func TestQueryBuilder_OrderBy(t *testing.T) {
 	t.Parallel()
 
@@ -361,7 +465,7 @@ func TestQueryBuilder_Build(t *testing.T) {
 					From("employees").
 					Where(domain.Filter{Field: "department", Op: domain.In, Value: []string{"HR", "Engineering"}})
 			},
-			wantQuery: "SELECT id, name FROM employees WHERE department IN (?)",
+			wantQuery: "SELECT id, name FROM employees WHERE department IN (?, ?)",
 			wantErr:   false,
 		},
 		{
@@ -405,6 +509,90 @@ func TestQueryBuilder_Build(t *testing.T) {
 			wantQuery: "SELECT id, name, email FROM employees WHERE department = ? AND location = ? ORDER BY name ASC, id DESC",
 			wantErr:   false,
 		},
+		{
+			name: "DollarPlaceholders",
+			setupFunc: func(qb *QueryBuilder[any]) {
+				qb.Placeholder(Dollar).
+					Select("id", "name").
+					From("employees").
+					Where(domain.Filter{Field: "department", Op: domain.Eq, Value: "Engineering"}).
+					Where(domain.Filter{Field: "name", Op: domain.Contains, Value: "an"}).
+					Where(domain.Filter{Field: "age", Op: domain.Between, Value: []int{20, 30}})
+			},
+			wantQuery: "SELECT id, name FROM employees " +
+				"WHERE department = $1 AND name LIKE '%' || $2 || '%' AND age BETWEEN $3 AND $4",
+			wantErr: false,
+		},
+		{
+			name: "DollarPlaceholdersWithExpandedInList",
+			setupFunc: func(qb *QueryBuilder[any]) {
+				qb.Placeholder(Dollar).
+					Select("id").
+					From("tenants").
+					Where(domain.Filter{Field: "status", Op: domain.In, Value: []int{1, 2}}).
+					Where(domain.Filter{Field: "deleted_at", Op: domain.Null}).
+					Where(domain.Filter{Field: "name", Op: domain.StartsWith, Value: "VN"})
+			},
+			wantQuery: "SELECT id FROM tenants WHERE status IN ($1, $2) AND deleted_at IS NULL AND name LIKE $3 || '%'",
+			wantErr:   false,
+		},
+		{
+			name: "DollarPlaceholdersWithArrayBinding",
+			setupFunc: func(qb *QueryBuilder[any]) {
+				qb.Placeholder(Dollar).
+					BindArrays().
+					Select("id").
+					From("tenants").
+					Where(domain.Filter{Field: "status", Op: domain.NotIn, Value: []int{3, 4}}).
+					Where(domain.Filter{Field: "name", Op: domain.Eq, Value: "VN"})
+			},
+			wantQuery: "SELECT id FROM tenants WHERE status <> ALL($1) AND name = $2",
+			wantErr:   false,
+		},
+		{
+			name: "DollarPlaceholdersWithFilterGroups",
+			setupFunc: func(qb *QueryBuilder[any]) {
+				qb.Placeholder(Dollar).
+					Select("id").
+					From("tenants").
+					Where(domain.Filter{Field: "deleted_at", Op: domain.Null}).
+					Where(domain.AnyOf(
+						domain.Filter{Field: "status", Op: domain.Eq, Value: 1},
+						domain.AllOf(
+							domain.Filter{Field: "subscription_type", Op: domain.Eq, Value: 2},
+							domain.Filter{Field: "name", Op: domain.StartsWith, Value: "VN"},
+						),
+					))
+			},
+			wantQuery: "SELECT id FROM tenants WHERE deleted_at IS NULL " +
+				"AND (status = $1 OR (subscription_type = $2 AND name LIKE $3 || '%'))",
+			wantErr: false,
+		},
+		{
+			name: "DollarPlaceholdersWithCaseInsensitiveFilters",
+			setupFunc: func(qb *QueryBuilder[any]) {
+				qb.Placeholder(Dollar).
+					Select("id").
+					From("users").
+					Where(domain.Filter{Field: "email", Op: domain.EndsWith, Value: "@vn.com", CaseSensitive: true}).
+					Where(domain.Filter{Field: "name", Op: domain.Eq, Value: "An", CaseSensitive: true}).
+					Paginate(domain.Pagination{Limit: 10})
+			},
+			wantQuery: "SELECT id FROM users WHERE LOWER(email) LIKE '%' || LOWER($1) AND LOWER(name) = LOWER($2) LIMIT 10",
+			wantErr:   false,
+		},
+		{
+			name: "DollarPlaceholdersIgnoreQuotedQuestionMarks",
+			setupFunc: func(qb *QueryBuilder[any]) {
+				qb.Placeholder(Dollar).
+					Select("id").
+					From("posts").
+					WhereRaw("title <> '?'").
+					Where(domain.Filter{Field: "author", Op: domain.Eq, Value: "An"})
+			},
+			wantQuery: "SELECT id FROM posts WHERE title <> '?' AND author = $1",
+			wantErr:   false,
+		},
 	}
 
 	for _, tt := range tests {
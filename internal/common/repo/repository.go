@@ -0,0 +1,391 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/errs"
+)
+
+// Repository implements the queries that every entity needs over the table described by the db tags of T.
+// Entity repositories embed it and add their own queries on top. Every method runs on the transaction of the
+// context when there is one.
+//
+// Queries hide the rows marked by the softdelete column of the table, unless the repository is Unscoped, and
+// Save bumps its version column.
+type Repository[T any] struct {
+	DB    *sql.DB
+	Table *domain.Table
+	// Key is the primary key column.
+	Key string
+
+	unscoped bool
+}
+
+// NewRepository describes the table of T, which must have a single column tagged pk.
+func NewRepository[T any](db *sql.DB, tableName string) (*Repository[T], error) {
+	var entity T
+
+	table, err := domain.StructToTable(entity, tableName)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(table.PrimaryKey) != 1 {
+		return nil, errors.Errorf("repository: table %s needs exactly one column tagged pk, got %d",
+			tableName, len(table.PrimaryKey))
+	}
+
+	return &Repository[T]{DB: db, Table: table, Key: table.PrimaryKey[0]}, nil
+}
+
+// Unscoped returns a copy of the repository whose queries and updates include the soft-deleted rows, to restore
+// or purge them.
+func (r *Repository[T]) Unscoped() *Repository[T] {
+	unscoped := *r
+	unscoped.unscoped = true
+
+	return &unscoped
+}
+
+// Select starts a query for every column of the visible rows of the table.
+func (r *Repository[T]) Select() *QueryBuilder[T] {
+	return r.visible(NewQueryBuilder[T]().
+		Placeholder(Dollar).
+		Select(r.Table.Columns...).
+		From(r.Table.Name))
+}
+
+// FindByID returns the row with the given primary key, or a NotFound error wrapping sql.ErrNoRows.
+func (r *Repository[T]) FindByID(ctx context.Context, id any) (*T, error) {
+	return r.findOne(ctx, fmt.Sprint(id), r.keyIs(id))
+}
+
+// FindOne returns the first row that matches all the conditions, or a NotFound error wrapping sql.ErrNoRows.
+func (r *Repository[T]) FindOne(ctx context.Context, conditions ...domain.Condition) (*T, error) {
+	return r.findOne(ctx, "", conditions...)
+}
+
+func (r *Repository[T]) findOne(ctx context.Context, name string, conditions ...domain.Condition) (*T, error) {
+	items, err := where(r.Select(), conditions).
+		Paginate(domain.Pagination{Limit: 1}).
+		QueryAll(ctx, Conn(ctx, r.DB))
+	if err != nil {
+		return nil, errors.Wrapf(err, "repository: failed to find %s", r.Table.Name)
+	}
+
+	if len(items) == 0 {
+		return nil, r.notFound(name)
+	}
+
+	return items[0], nil
+}
+
+// FindAllByID returns the rows with the given primary keys, in no particular order.
+func (r *Repository[T]) FindAllByID(ctx context.Context, ids ...any) ([]*T, error) {
+	if len(ids) == 0 {
+		return make([]*T, 0), nil
+	}
+
+	return r.FindAllBy(ctx, domain.Filter{Field: r.Key, Op: domain.In, Value: ids})
+}
+
+// FindAllBy returns every row that matches all the conditions.
+func (r *Repository[T]) FindAllBy(ctx context.Context, conditions ...domain.Condition) ([]*T, error) {
+	return r.FindAll(ctx, &domain.ListRequest{Filters: conditions})
+}
+
+// FindAll returns a page of the rows that match the filters of the request, in the order of its sorts. A
+// request with a cursor reads the page that comes after (or before) it.
+func (r *Repository[T]) FindAll(ctx context.Context, request *domain.ListRequest) ([]*T, error) {
+	builder := where(r.Select(), request.Filters)
+	sorts := request.Sorts
+
+	if cursor := request.Pagination.Cursor; cursor != nil {
+		builder = builder.Keyset(*cursor, request.Sorts)
+
+		if cursor.Backward {
+			sorts = ReverseSorts(sorts)
+		}
+	}
+
+	for _, sort := range sorts {
+		builder = builder.OrderBy(sort)
+	}
+
+	items, err := builder.
+		Paginate(domain.Pagination{
+			Offset: request.Pagination.Offset,
+			Limit:  request.Pagination.Limit,
+		}).
+		QueryAll(ctx, Conn(ctx, r.DB))
+	if err != nil {
+		return nil, errors.Wrapf(err, "repository: failed to find %s", r.Table.Name)
+	}
+
+	if items == nil {
+		items = make([]*T, 0)
+	}
+
+	return items, nil
+}
+
+// Exists reports whether any row matches all the conditions.
+func (r *Repository[T]) Exists(ctx context.Context, conditions ...domain.Condition) (bool, error) {
+	builder := r.visible(NewQueryBuilder[T]().
+		Placeholder(Dollar).
+		SelectExists().
+		From(r.Table.Name))
+
+	exists, err := where(builder, conditions).Exist(ctx, Conn(ctx, r.DB))
+	if err != nil {
+		return false, errors.Wrapf(err, "repository: failed to check %s", r.Table.Name)
+	}
+
+	return exists, nil
+}
+
+// Count returns the number of rows that match all the conditions.
+func (r *Repository[T]) Count(ctx context.Context, conditions ...domain.Condition) (int64, error) {
+	builder := r.visible(NewQueryBuilder[T]().
+		Placeholder(Dollar).
+		SelectCount().
+		From(r.Table.Name))
+
+	count, err := where(builder, conditions).Count(ctx, Conn(ctx, r.DB))
+	if err != nil {
+		return 0, errors.Wrapf(err, "repository: failed to count %s", r.Table.Name)
+	}
+
+	return count, nil
+}
+
+// Save inserts the entity or updates its mutable columns when its primary key already exists, then refreshes
+// it from the stored row. It returns an AlreadyExists error when the entity breaks another unique constraint.
+func (r *Repository[T]) Save(ctx context.Context, entity *T) error {
+	saved, err := NewInsertBuilder[T]().
+		Placeholder(Dollar).
+		InsertInto(r.Table.Name).
+		Values(entity, r.Table.Insertable...).
+		OnConflictDoUpdateSet(r.Table.PrimaryKey, r.conflictSetters()...).
+		Returning(r.Table.Columns...).
+		Query(ctx, Conn(ctx, r.DB))
+	if err != nil {
+		return r.saveError(err)
+	}
+
+	*entity = *saved
+
+	return nil
+}
+
+// Update writes the given mutable columns of the entity, or all of them but the softdelete column when no column
+// is given, if its row is still at the version that the entity holds, bumps that version and refreshes the entity
+// from the stored row. An entity without a version is written whatever the version of its row. It returns a
+// Conflict error wrapping a *ConflictError when the row has been changed since and a NotFound error wrapping
+// sql.ErrNoRows when the row is gone.
+func (r *Repository[T]) Update(ctx context.Context, entity *T, columns ...string) error {
+	version := r.Table.Version
+	if version == "" {
+		return errors.Errorf("repository: table %s has no column tagged version", r.Table.Name)
+	}
+
+	values, err := domain.StructValues(entity, r.Key, version)
+	if err != nil {
+		return err
+	}
+
+	setters, err := ToSetters(entity)
+	if err != nil {
+		return err
+	}
+
+	if len(columns) == 0 {
+		columns = r.Table.Updatable
+	}
+
+	for _, column := range columns {
+		if !slices.Contains(r.Table.Updatable, column) && column != r.Table.SoftDelete {
+			return errors.Errorf("repository: column %s of %s cannot be updated", column, r.Table.Name)
+		}
+	}
+
+	if setters, err = PickSetters(setters, columns...); err != nil {
+		return err
+	}
+
+	builder := NewUpdateBuilder[T]().
+		Placeholder(Dollar).
+		Update(r.Table.Name).
+		SetValues(append(setters, Setter{Field: version, Value: Expr(version + " + 1")})...).
+		Where(r.keyIs(values[0]))
+
+	unconditional := reflect.ValueOf(values[1]).IsZero()
+	if !unconditional {
+		builder = builder.Where(domain.Filter{Field: version, Op: domain.Eq, Value: values[1]})
+	}
+
+	if r.Table.SoftDelete != "" && !r.unscoped {
+		builder = builder.Where(domain.Filter{Field: r.Table.SoftDelete, Op: domain.Null})
+	}
+
+	updated, err := builder.
+		Returning(r.Table.Columns...).
+		Query(ctx, Conn(ctx, r.DB))
+	if errors.Is(err, sql.ErrNoRows) {
+		if unconditional {
+			return r.notFound(fmt.Sprint(values[0]))
+		}
+
+		return r.conflictOrNotFound(ctx, values[0], values[1])
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "repository: failed to update %s", r.Table.Name)
+	}
+
+	*entity = *updated
+
+	return nil
+}
+
+// SaveAll is Save for many entities, in as few statements as the bind parameter limit allows. Unlike Save, it
+// does not refresh the entities, since PostgreSQL does not guarantee the order of the returned rows. It must run
+// in a UnitOfWork when the entities do not fit in one statement.
+func (r *Repository[T]) SaveAll(ctx context.Context, entities []*T) (int64, error) {
+	if len(entities) == 0 {
+		return 0, nil
+	}
+
+	saved, err := NewInsertBuilder[T]().
+		Placeholder(Dollar).
+		InsertInto(r.Table.Name).
+		ValuesBatch(entities, r.Table.Insertable...).
+		OnConflictDoUpdateSet(r.Table.PrimaryKey, r.conflictSetters()...).
+		Exec(ctx, Conn(ctx, r.DB))
+	if err != nil {
+		return 0, r.saveError(err)
+	}
+
+	return saved, nil
+}
+
+// Delete removes the row with the given primary key, or returns a NotFound error wrapping sql.ErrNoRows when
+// there is none.
+func (r *Repository[T]) Delete(ctx context.Context, id any) error {
+	deleted, err := r.DeleteAllBy(ctx, r.keyIs(id))
+	if err != nil {
+		return err
+	}
+
+	if deleted == 0 {
+		return r.notFound(fmt.Sprint(id))
+	}
+
+	return nil
+}
+
+// DeleteAllByID removes the rows with the given primary keys and returns how many there were.
+func (r *Repository[T]) DeleteAllByID(ctx context.Context, ids ...any) (int64, error) {
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	return r.DeleteAllBy(ctx, domain.Filter{Field: r.Key, Op: domain.In, Value: ids})
+}
+
+// DeleteAllBy removes the rows that match all the conditions, of which there must be at least one, and returns
+// how many there were.
+func (r *Repository[T]) DeleteAllBy(ctx context.Context, conditions ...domain.Condition) (int64, error) {
+	builder := NewDeleteBuilder[T]().
+		Placeholder(Dollar).
+		DeleteFrom(r.Table.Name)
+
+	for _, condition := range conditions {
+		builder = builder.Where(condition)
+	}
+
+	deleted, err := builder.Exec(ctx, Conn(ctx, r.DB))
+	if err != nil {
+		return 0, errors.Wrapf(err, "repository: failed to delete %s", r.Table.Name)
+	}
+
+	return deleted, nil
+}
+
+// conflictOrNotFound explains why an update matched no row: either the row is gone or it is at another version.
+func (r *Repository[T]) conflictOrNotFound(ctx context.Context, key any, version any) error {
+	exists, err := r.Exists(ctx, r.keyIs(key))
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return r.notFound(fmt.Sprint(key))
+	}
+
+	return errs.New(errs.Conflict, "%s has been modified since version %v", r.Table.Name, version).
+		WithReason(ReasonVersionMismatch).
+		WithResource(r.Table.Name, fmt.Sprint(key)).
+		Wrap(&ConflictError{Table: r.Table.Name, Key: key, Version: version})
+}
+
+func (r *Repository[T]) notFound(name string) error {
+	return errs.NewNotFound(r.Table.Name, name).Wrap(sql.ErrNoRows)
+}
+
+// saveError reports a unique violation as an AlreadyExists error, naming the constraint in its metadata.
+func (r *Repository[T]) saveError(err error) error {
+	if !IsUniqueViolation(err) {
+		return errors.Wrapf(err, "repository: failed to save %s", r.Table.Name)
+	}
+
+	var pqErr *pq.Error
+	_ = errors.As(err, &pqErr)
+
+	return errs.NewAlreadyExists(r.Table.Name, "").
+		WithMetadata("constraint", pqErr.Constraint).
+		Wrap(err)
+}
+
+// conflictSetters updates the mutable columns of an existing row with the inserted values and bumps its version.
+func (r *Repository[T]) conflictSetters() []Setter {
+	setters := make([]Setter, 0, len(r.Table.Updatable)+1)
+
+	for _, column := range r.Table.Updatable {
+		setters = append(setters, Setter{Field: column, Value: Expr("EXCLUDED." + column)})
+	}
+
+	if version := r.Table.Version; version != "" {
+		setters = append(setters, Setter{Field: version, Value: Expr(r.Table.Name + "." + version + " + 1")})
+	}
+
+	return setters
+}
+
+// visible hides the soft-deleted rows from the query, unless the repository is unscoped.
+func (r *Repository[T]) visible(builder *QueryBuilder[T]) *QueryBuilder[T] {
+	if r.Table.SoftDelete == "" || r.unscoped {
+		return builder
+	}
+
+	return builder.Where(domain.Filter{Field: r.Table.SoftDelete, Op: domain.Null})
+}
+
+func (r *Repository[T]) keyIs(id any) domain.Filter {
+	return domain.Filter{Field: r.Key, Op: domain.Eq, Value: id}
+}
+
+func where[T any](builder *QueryBuilder[T], conditions []domain.Condition) *QueryBuilder[T] {
+	for _, condition := range conditions {
+		builder = builder.Where(condition)
+	}
+
+	return builder
+}
@@ -0,0 +1,400 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+type testMember struct {
+	ID   int    `db:"id,pk"`
+	Name string `db:"name"`
+}
+
+func newTestRepository(t *testing.T) (*Repository[testMember], *recorder) {
+	t.Helper()
+
+	db, rec := newRecordingDB(t)
+
+	repository, err := NewRepository[testMember](db, "member")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return repository, rec
+}
+
+func TestRepository_Statements(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		columns []string
+		rows    [][]driver.Value
+		run     func(ctx context.Context, repository *Repository[testMember]) error
+		want    []string
+	}{
+		{
+			name:    "FindByID",
+			columns: []string{"id", "name"},
+			rows:    [][]driver.Value{{int64(1), "An"}},
+			run: func(ctx context.Context, repository *Repository[testMember]) error {
+				_, err := repository.FindByID(ctx, 1)
+
+				return err
+			},
+			want: []string{"SELECT id, name FROM member WHERE id = $1 LIMIT 1"},
+		},
+		{
+			name:    "FindAllByID",
+			columns: []string{"id", "name"},
+			run: func(ctx context.Context, repository *Repository[testMember]) error {
+				_, err := repository.FindAllByID(ctx, 1, 2)
+
+				return err
+			},
+			want: []string{"SELECT id, name FROM member WHERE id IN ($1, $2)"},
+		},
+		{
+			name:    "Exists",
+			columns: []string{"exists"},
+			rows:    [][]driver.Value{{true}},
+			run: func(ctx context.Context, repository *Repository[testMember]) error {
+				_, err := repository.Exists(ctx, domain.Filter{Field: "name", Op: domain.Eq, Value: "An"})
+
+				return err
+			},
+			want: []string{"SELECT 1 FROM member WHERE name = $1 LIMIT 1"},
+		},
+		{
+			name:    "Count",
+			columns: []string{"count"},
+			rows:    [][]driver.Value{{int64(2)}},
+			run: func(ctx context.Context, repository *Repository[testMember]) error {
+				_, err := repository.Count(ctx)
+
+				return err
+			},
+			want: []string{"SELECT COUNT(1) FROM member"},
+		},
+		{
+			name:    "Save",
+			columns: []string{"id", "name"},
+			rows:    [][]driver.Value{{int64(1), "An"}},
+			run: func(ctx context.Context, repository *Repository[testMember]) error {
+				return repository.Save(ctx, &testMember{ID: 1, Name: "An"})
+			},
+			want: []string{
+				"INSERT INTO member (id, name) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET name = EXCLUDED.name " +
+					"RETURNING id, name",
+			},
+		},
+		{
+			name: "SaveAll",
+			run: func(ctx context.Context, repository *Repository[testMember]) error {
+				_, err := repository.SaveAll(ctx, []*testMember{{ID: 1, Name: "An"}, {ID: 2, Name: "Binh"}})
+
+				return err
+			},
+			want: []string{
+				"INSERT INTO member (id, name) VALUES ($1, $2), ($3, $4) ON CONFLICT (id) DO UPDATE SET " +
+					"name = EXCLUDED.name",
+			},
+		},
+		{
+			name: "Delete",
+			run: func(ctx context.Context, repository *Repository[testMember]) error {
+				return repository.Delete(ctx, 1)
+			},
+			want: []string{"DELETE FROM member WHERE id = $1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repository, rec := newTestRepository(t)
+			rec.Returns(tt.columns, tt.rows...)
+
+			gotErr := tt.run(context.Background(), repository)
+
+			fixture.ExpectationsWereMet(t, tt.want, rec.Statements(), false, gotErr)
+		})
+	}
+}
+
+type testDocument struct {
+	ID        int        `db:"id,pk"`
+	Title     string     `db:"title"`
+	Version   int        `db:"version,version,default=1"`
+	DeletedAt *time.Time `db:"deleted_at,softdelete"`
+}
+
+func TestRepository_TagOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		run  func(ctx context.Context, repository *Repository[testDocument]) error
+		want []string
+	}{
+		{
+			name: "FindByID Hides Soft Deleted Rows",
+			run: func(ctx context.Context, repository *Repository[testDocument]) error {
+				_, err := repository.FindByID(ctx, 1)
+
+				return err
+			},
+			want: []string{
+				"SELECT id, title, version, deleted_at FROM document WHERE deleted_at IS NULL AND id = $1 LIMIT 1",
+			},
+		},
+		{
+			name: "Exists Hides Soft Deleted Rows",
+			run: func(ctx context.Context, repository *Repository[testDocument]) error {
+				_, err := repository.Exists(ctx)
+
+				return err
+			},
+			want: []string{"SELECT 1 FROM document WHERE deleted_at IS NULL LIMIT 1"},
+		},
+		{
+			name: "Unscoped FindByID Includes Soft Deleted Rows",
+			run: func(ctx context.Context, repository *Repository[testDocument]) error {
+				_, err := repository.Unscoped().FindByID(ctx, 1)
+
+				return err
+			},
+			want: []string{"SELECT id, title, version, deleted_at FROM document WHERE id = $1 LIMIT 1"},
+		},
+		{
+			name: "Unscoped Update Includes Soft Deleted Rows",
+			run: func(ctx context.Context, repository *Repository[testDocument]) error {
+				return repository.Unscoped().Update(ctx, &testDocument{ID: 1, Version: 1}, "deleted_at")
+			},
+			want: []string{
+				"UPDATE document SET deleted_at = $1, version = version + 1 WHERE id = $2 AND version = $3 " +
+					"RETURNING id, title, version, deleted_at",
+			},
+		},
+		{
+			name: "Save Bumps Version",
+			run: func(ctx context.Context, repository *Repository[testDocument]) error {
+				return repository.Save(ctx, &testDocument{ID: 1, Title: "Draft"})
+			},
+			want: []string{
+				"INSERT INTO document (id, title, version, deleted_at) VALUES ($1, $2, 1, $3) ON CONFLICT (id) " +
+					"DO UPDATE SET title = EXCLUDED.title, version = document.version + 1 " +
+					"RETURNING id, title, version, deleted_at",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, rec := newRecordingDB(t)
+			rec.Returns([]string{"id", "title", "version", "deleted_at"}, []driver.Value{int64(1), "Draft", int64(1), nil})
+
+			repository, err := NewRepository[testDocument](db, "document")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotErr := tt.run(context.Background(), repository)
+
+			fixture.ExpectationsWereMet(t, tt.want, rec.Statements(), false, gotErr)
+		})
+	}
+}
+
+func TestNewRepository_WithoutPrimaryKey(t *testing.T) {
+	t.Parallel()
+
+	db, _ := newRecordingDB(t)
+
+	got, gotErr := NewRepository[testUser](db, "users")
+
+	fixture.ExpectationsWereMet(t, (*Repository[testUser])(nil), got, true, gotErr)
+}
+
+func TestRepository_FindByID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		rows    [][]driver.Value
+		want    *testMember
+		wantErr bool
+	}{
+		{
+			name:    "FindByID With Existing Row",
+			rows:    [][]driver.Value{{int64(1), "An"}},
+			want:    &testMember{ID: 1, Name: "An"},
+			wantErr: false,
+		},
+		{
+			name:    "FindByID With No Row",
+			rows:    nil,
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			repository, rec := newTestRepository(t)
+			rec.Returns([]string{"id", "name"}, tt.rows...)
+
+			got, gotErr := repository.FindByID(context.Background(), 1)
+
+			if tt.wantErr && !errors.Is(gotErr, sql.ErrNoRows) {
+				t.Errorf("FindByID() error = %v, want sql.ErrNoRows", gotErr)
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestRepository_Update(t *testing.T) {
+	t.Parallel()
+
+	columns := []string{"id", "title", "version", "deleted_at"}
+
+	tests := []struct {
+		name      string
+		document  *testDocument
+		results   [][][]driver.Value
+		columns   []string
+		want      []string
+		wantErrIs func(err error) bool
+	}{
+		{
+			name:     "Update At Current Version",
+			document: &testDocument{ID: 1, Title: "Final", Version: 2},
+			results:  [][][]driver.Value{{{int64(1), "Final", int64(3), nil}}},
+			want: []string{
+				"UPDATE document SET title = $1, version = version + 1 " +
+					"WHERE id = $2 AND version = $3 AND deleted_at IS NULL RETURNING id, title, version, deleted_at",
+			},
+			wantErrIs: nil,
+		},
+		{
+			name:     "Update Given Columns",
+			document: &testDocument{ID: 1, Title: "Final", Version: 2},
+			results:  [][][]driver.Value{{{int64(1), "Final", int64(3), nil}}},
+			columns:  []string{"title"},
+			want: []string{
+				"UPDATE document SET title = $1, version = version + 1 " +
+					"WHERE id = $2 AND version = $3 AND deleted_at IS NULL RETURNING id, title, version, deleted_at",
+			},
+			wantErrIs: nil,
+		},
+		{
+			name:     "Update Soft Delete Column When Named",
+			document: &testDocument{ID: 1, Title: "Final", Version: 2},
+			results:  [][][]driver.Value{{{int64(1), "Final", int64(3), nil}}},
+			columns:  []string{"title", "deleted_at"},
+			want: []string{
+				"UPDATE document SET title = $1, deleted_at = $2, version = version + 1 " +
+					"WHERE id = $3 AND version = $4 AND deleted_at IS NULL RETURNING id, title, version, deleted_at",
+			},
+			wantErrIs: nil,
+		},
+		{
+			name:     "Update Immutable Column",
+			document: &testDocument{ID: 1, Title: "Final", Version: 2},
+			columns:  []string{"id"},
+			want:     nil,
+			wantErrIs: func(err error) bool {
+				return err != nil
+			},
+		},
+		{
+			name:     "Update At Stale Version",
+			document: &testDocument{ID: 1, Title: "Final", Version: 1},
+			results:  [][][]driver.Value{nil, {{int64(1)}}},
+			want: []string{
+				"UPDATE document SET title = $1, version = version + 1 " +
+					"WHERE id = $2 AND version = $3 AND deleted_at IS NULL RETURNING id, title, version, deleted_at",
+				"SELECT 1 FROM document WHERE deleted_at IS NULL AND id = $1 LIMIT 1",
+			},
+			wantErrIs: func(err error) bool {
+				var conflict *ConflictError
+
+				return errors.As(err, &conflict)
+			},
+		},
+		{
+			name:     "Update Missing Row",
+			document: &testDocument{ID: 1, Title: "Final", Version: 1},
+			results:  [][][]driver.Value{nil},
+			want: []string{
+				"UPDATE document SET title = $1, version = version + 1 " +
+					"WHERE id = $2 AND version = $3 AND deleted_at IS NULL RETURNING id, title, version, deleted_at",
+				"SELECT 1 FROM document WHERE deleted_at IS NULL AND id = $1 LIMIT 1",
+			},
+			wantErrIs: func(err error) bool {
+				return errors.Is(err, sql.ErrNoRows)
+			},
+		},
+		{
+			name:     "Update Without Version",
+			document: &testDocument{ID: 1, Title: "Final"},
+			results:  [][][]driver.Value{{{int64(1), "Final", int64(3), nil}}},
+			want: []string{
+				"UPDATE document SET title = $1, version = version + 1 " +
+					"WHERE id = $2 AND deleted_at IS NULL RETURNING id, title, version, deleted_at",
+			},
+			wantErrIs: nil,
+		},
+		{
+			name:     "Update Missing Row Without Version",
+			document: &testDocument{ID: 1, Title: "Final"},
+			results:  [][][]driver.Value{nil},
+			want: []string{
+				"UPDATE document SET title = $1, version = version + 1 " +
+					"WHERE id = $2 AND deleted_at IS NULL RETURNING id, title, version, deleted_at",
+			},
+			wantErrIs: func(err error) bool {
+				return errors.Is(err, sql.ErrNoRows)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, rec := newRecordingDB(t)
+
+			for _, rows := range tt.results {
+				rec.Returns(columns, rows...)
+			}
+
+			repository, err := NewRepository[testDocument](db, "document")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			gotErr := repository.Update(context.Background(), tt.document, tt.columns...)
+
+			if tt.wantErrIs != nil && !tt.wantErrIs(gotErr) {
+				t.Errorf("Update() error = %v", gotErr)
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, rec.Statements(), tt.wantErrIs != nil, gotErr)
+		})
+	}
+}
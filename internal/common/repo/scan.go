@@ -0,0 +1,152 @@
+package repo
+
+import (
+	"database/sql"
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+)
+
+var (
+	scannerType = reflect.TypeFor[sql.Scanner]()
+	timeType    = reflect.TypeFor[time.Time]()
+	bytesType   = reflect.TypeFor[[]byte]()
+
+	// scanFields caches, per struct type, the field of every column named by a db tag.
+	scanFields sync.Map
+)
+
+// scanField is the struct field that a column is scanned into.
+type scanField struct {
+	index []int
+	json  bool
+}
+
+// ScanStruct is the default Scanner. It maps every column of the result to the field of T whose db tag names it,
+// including the fields of embedded structs, and fails on columns without a field.
+//
+// Besides the types that database/sql scans natively, including pointers for nullable columns and sql.Scanner
+// implementations such as sql.Null* and uuid.UUID, it scans arrays into slices and JSON or JSONB documents into
+// maps, structs and slices of structs, as well as into any field tagged json or jsonb.
+func ScanStruct[T any](rows *sql.Rows, out *T) error {
+	value := reflect.ValueOf(out).Elem()
+
+	if value.Kind() != reflect.Struct {
+		return errors.Errorf("repository: cannot scan into %s, a struct is required", value.Type())
+	}
+
+	columns, err := rows.Columns()
+	if err != nil {
+		return err
+	}
+
+	fields := structScanFields(value.Type())
+	dest := make([]any, 0, len(columns))
+
+	for _, column := range columns {
+		field, ok := fields[column]
+		if !ok {
+			return errors.Errorf("repository: no field of %s is tagged with column %s", value.Type(), column)
+		}
+
+		if field.json {
+			dest = append(dest, jsonScanner{dest: value.FieldByIndex(field.index).Addr().Interface()})
+		} else {
+			dest = append(dest, scanDestination(value.FieldByIndex(field.index)))
+		}
+	}
+
+	return rows.Scan(dest...)
+}
+
+func structScanFields(typ reflect.Type) map[string]scanField {
+	if cached, ok := scanFields.Load(typ); ok {
+		return cached.(map[string]scanField) //nolint:forcetypeassert
+	}
+
+	fields := make(map[string]scanField)
+	collectScanFields(typ, nil, fields)
+
+	cached, _ := scanFields.LoadOrStore(typ, fields)
+
+	return cached.(map[string]scanField) //nolint:forcetypeassert
+}
+
+func collectScanFields(typ reflect.Type, parent []int, fields map[string]scanField) {
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		index := append(append(make([]int, 0, len(parent)+1), parent...), i)
+		tag := field.Tag.Get("db")
+
+		switch {
+		case tag == "" && field.Anonymous && field.Type.Kind() == reflect.Struct:
+			collectScanFields(field.Type, index, fields)
+		case tag == "" || tag == "-" || !field.IsExported():
+			continue
+		default:
+			// Invalid tags are reported when the table is described, so they only lose their options here.
+			column, err := domain.ParseTag(tag, field.Name)
+			if err != nil {
+				column.Name, _, _ = strings.Cut(tag, ",")
+			}
+
+			if _, exists := fields[column.Name]; !exists {
+				fields[column.Name] = scanField{index: index, json: column.JSON}
+			}
+		}
+	}
+}
+
+// scanDestination returns what to pass to rows.Scan to fill the field.
+func scanDestination(field reflect.Value) any {
+	addr := field.Addr()
+	typ := field.Type()
+
+	switch {
+	case addr.Type().Implements(scannerType), typ == timeType, typ == bytesType:
+		return addr.Interface()
+	case typ.Kind() == reflect.Map, typ.Kind() == reflect.Struct, isStructSlice(typ):
+		return jsonScanner{dest: addr.Interface()}
+	case typ.Kind() == reflect.Slice:
+		return pq.Array(addr.Interface())
+	default:
+		return addr.Interface()
+	}
+}
+
+func isStructSlice(typ reflect.Type) bool {
+	if typ.Kind() != reflect.Slice {
+		return false
+	}
+
+	elem := typ.Elem()
+	if elem.Kind() == reflect.Pointer {
+		elem = elem.Elem()
+	}
+
+	return elem.Kind() == reflect.Struct && elem != timeType
+}
+
+// jsonScanner decodes a JSON or JSONB column into dest, leaving it untouched when the column is NULL.
+type jsonScanner struct {
+	dest any
+}
+
+func (s jsonScanner) Scan(src any) error {
+	switch data := src.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return errors.Wrap(json.Unmarshal(data, s.dest), "repository: cannot decode json column")
+	case string:
+		return errors.Wrap(json.Unmarshal([]byte(data), s.dest), "repository: cannot decode json column")
+	default:
+		return errors.Errorf("repository: cannot decode json column from %T", src)
+	}
+}
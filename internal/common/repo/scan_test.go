@@ -0,0 +1,123 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+type testAudit struct {
+	CreatedAt time.Time `db:"created_at"`
+}
+
+type testAccount struct {
+	testAudit
+
+	ID       uuid.UUID         `db:"id,pk"`
+	Name     string            `db:"name"`
+	Nickname *string           `db:"nickname"`
+	Phone    sql.NullString    `db:"phone"`
+	Roles    []string          `db:"roles"`
+	Settings map[string]string `db:"settings"`
+	Labels   []string          `db:"labels,jsonb"`
+	Secret   string            `db:"-"`
+}
+
+func TestScanStruct(t *testing.T) {
+	t.Parallel()
+
+	id := uuid.MustParse("0f8fad5b-d9cb-469f-a165-70867728950e")
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	nickname := "An"
+
+	tests := []struct {
+		name    string
+		columns []string
+		rows    [][]driver.Value
+		want    []*testAccount
+		wantErr bool
+	}{
+		{
+			name:    "Scan With All Supported Types",
+			columns: []string{"id", "name", "nickname", "phone", "roles", "settings", "labels", "created_at"},
+			rows: [][]driver.Value{{
+				id.String(), "Nguyen Van An", nickname, "0901", []byte(`{"admin","owner"}`), []byte(`{"lang":"vi"}`),
+				[]byte(`["vip"]`), createdAt,
+			}},
+			want: []*testAccount{{
+				testAudit: testAudit{CreatedAt: createdAt},
+				ID:        id,
+				Name:      "Nguyen Van An",
+				Nickname:  &nickname,
+				Phone:     sql.NullString{String: "0901", Valid: true},
+				Roles:     []string{"admin", "owner"},
+				Settings:  map[string]string{"lang": "vi"},
+				Labels:    []string{"vip"},
+			}},
+			wantErr: false,
+		},
+		{
+			name:    "Scan With Null Values",
+			columns: []string{"name", "nickname", "phone", "roles", "settings"},
+			rows:    [][]driver.Value{{"Nguyen Van An", nil, nil, nil, nil}},
+			want:    []*testAccount{{Name: "Nguyen Van An"}},
+			wantErr: false,
+		},
+		{
+			name:    "Scan With Columns In Any Order",
+			columns: []string{"name", "id"},
+			rows:    [][]driver.Value{{"A", id.String()}, {"B", id.String()}},
+			want:    []*testAccount{{ID: id, Name: "A"}, {ID: id, Name: "B"}},
+			wantErr: false,
+		},
+		{
+			name:    "Scan With Unknown Column",
+			columns: []string{"name", "secret"},
+			rows:    [][]driver.Value{{"A", "hidden"}},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "Scan With Invalid JSON",
+			columns: []string{"settings"},
+			rows:    [][]driver.Value{{[]byte(`{`)}},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			db, rec := newRecordingDB(t)
+			rec.Returns(tt.columns, tt.rows...)
+
+			got, gotErr := NewQueryBuilder[testAccount]().
+				Select(tt.columns...).
+				From("accounts").
+				QueryAll(context.Background(), db)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestScanStruct_NonStruct(t *testing.T) {
+	t.Parallel()
+
+	db, rec := newRecordingDB(t)
+	rec.Returns([]string{"name"}, []driver.Value{"A"})
+
+	got, gotErr := NewQueryBuilder[string]().
+		Select("name").
+		From("accounts").
+		QueryAll(context.Background(), db)
+
+	fixture.ExpectationsWereMet(t, []*string(nil), got, true, gotErr)
+}
@@ -0,0 +1,144 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+)
+
+// Scanner reads the current row into out.
+type Scanner[T any] func(rows *sql.Rows, out *T) error
+
+// whereBuilder accumulates the conditions of the WHERE clause of an UPDATE or DELETE statement.
+type whereBuilder struct {
+	clause strings.Builder
+	args   []any
+}
+
+func (w *whereBuilder) add(condition domain.Condition, optAlias ...string) error {
+	whereClause, args, err := stringifyCondition(condition, false, optAlias...)
+	if err != nil {
+		return err
+	}
+
+	if w.clause.Len() > 0 {
+		w.clause.WriteString(" AND ")
+	} else {
+		w.clause.WriteString(" WHERE ")
+	}
+
+	w.clause.WriteString(whereClause)
+	w.args = append(w.args, args...)
+
+	return nil
+}
+
+func stringifyReturning(columns []string) (string, error) {
+	for _, column := range columns {
+		if column == "" {
+			return "", errors.New("repository: returning column is required")
+		}
+	}
+
+	return " RETURNING " + strings.Join(columns, ", "), nil
+}
+
+func stringifySetters(setters []Setter) (string, []any, error) {
+	assignments := make([]string, 0, len(setters))
+	args := make([]any, 0, len(setters))
+
+	for _, setter := range setters {
+		if setter.Field == "" {
+			return "", nil, errors.New("repository: field in setter is required")
+		}
+
+		if expr, ok := setter.Value.(Expr); ok {
+			assignments = append(assignments, setter.Field+" = "+string(expr))
+
+			continue
+		}
+
+		assignments = append(assignments, setter.Field+" = "+string(placeholder))
+		args = append(args, setter.Value)
+	}
+
+	return strings.Join(assignments, ", "), args, nil
+}
+
+func execStatement(ctx context.Context, db Executor, query string, args []any) (int64, error) {
+	result, err := db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return -1, err
+	}
+
+	return result.RowsAffected()
+}
+
+// scannerOf returns the first of the given scanners, or ScanStruct when there is none.
+func scannerOf[T any](scanners []Scanner[T]) Scanner[T] {
+	if len(scanners) > 0 && scanners[0] != nil {
+		return scanners[0]
+	}
+
+	return ScanStruct[T]
+}
+
+// queryStatement runs a statement with a RETURNING clause and scans every returned row.
+func queryStatement[T any](
+	ctx context.Context,
+	db Executor,
+	query string,
+	args []any,
+	scanner Scanner[T],
+) ([]*T, error) {
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+
+	defer func() {
+		_ = rows.Close()
+	}()
+
+	out := make([]*T, 0)
+
+	for rows.Next() {
+		var item T
+
+		if err = scanner(rows, &item); err != nil {
+			return nil, err
+		}
+
+		out = append(out, &item)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return out, nil
+}
+
+// queryOneStatement is queryStatement for statements that affect at most one row. It returns sql.ErrNoRows
+// when the statement returned nothing.
+func queryOneStatement[T any](
+	ctx context.Context,
+	db Executor,
+	query string,
+	args []any,
+	scanner Scanner[T],
+) (*T, error) {
+	out, err := queryStatement(ctx, db, query, args, scanner)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(out) == 0 {
+		return nil, sql.ErrNoRows
+	}
+
+	return out[0], nil
+}
@@ -0,0 +1,187 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"go.uber.org/fx"
+)
+
+const (
+	defaultTxRetries = 3
+	txRetryBackoff   = 20 * time.Millisecond
+
+	errCodeSerializationFailure = "40001"
+	errCodeDeadlockDetected     = "40P01"
+	errCodeUniqueViolation      = "23505"
+)
+
+// UnitOfWork runs a function in a database transaction. The transaction travels in the context given to the
+// function, and repositories join it through Conn.
+type UnitOfWork interface {
+	// Do commits the transaction when fn succeeds and rolls it back otherwise. Nested calls run in a savepoint
+	// of the surrounding transaction, which is rolled back alone when fn fails. They cannot change its isolation
+	// level and fail when they ask for another one; their other options are ignored.
+	Do(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error
+}
+
+type TxOption func(*txOptions)
+
+type txOptions struct {
+	isolation sql.IsolationLevel
+	readOnly  bool
+	retries   int
+}
+
+// WithIsolation sets the isolation level of the transaction. Defaults to the one of the database.
+func WithIsolation(level sql.IsolationLevel) TxOption {
+	return func(opts *txOptions) {
+		opts.isolation = level
+	}
+}
+
+// ReadOnly starts a read-only transaction.
+func ReadOnly() TxOption {
+	return func(opts *txOptions) {
+		opts.readOnly = true
+	}
+}
+
+// WithRetries sets how many times the whole transaction is run again after a serialization failure or a
+// deadlock. Defaults to 3.
+func WithRetries(retries int) TxOption {
+	return func(opts *txOptions) {
+		opts.retries = retries
+	}
+}
+
+type txKey struct{}
+
+type txState struct {
+	tx         *sql.Tx
+	isolation  sql.IsolationLevel
+	savepoints int
+}
+
+type UnitOfWorkParams struct {
+	fx.In
+	DB *sql.DB
+}
+
+func NewUnitOfWork(params UnitOfWorkParams) UnitOfWork {
+	return &unitOfWork{db: params.DB}
+}
+
+type unitOfWork struct {
+	db *sql.DB
+}
+
+func (u *unitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error, opts ...TxOption) error {
+	options := txOptions{retries: defaultTxRetries}
+
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if state, ok := ctx.Value(txKey{}).(*txState); ok {
+		if options.isolation != sql.LevelDefault && options.isolation != state.isolation {
+			return errors.Errorf("repository: nested transaction cannot run at %s isolation in a %s transaction",
+				options.isolation, state.isolation)
+		}
+
+		return savepoint(ctx, state, fn)
+	}
+
+	for attempt := 0; ; attempt++ {
+		err := u.run(ctx, fn, options)
+		if err == nil || attempt >= options.retries || !IsSerializationFailure(err) {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return errors.Wrap(ctx.Err(), "repository: transaction retry cancelled")
+		case <-time.After(txRetryBackoff << attempt):
+		}
+	}
+}
+
+func (u *unitOfWork) run(ctx context.Context, fn func(ctx context.Context) error, options txOptions) (err error) {
+	tx, err := u.db.BeginTx(ctx, &sql.TxOptions{Isolation: options.isolation, ReadOnly: options.readOnly})
+	if err != nil {
+		return errors.Wrap(err, "repository: cannot begin transaction")
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			_ = tx.Rollback()
+
+			panic(recovered)
+		}
+	}()
+
+	if err = fn(context.WithValue(ctx, txKey{}, &txState{tx: tx, isolation: options.isolation})); err != nil {
+		if rollbackErr := tx.Rollback(); rollbackErr != nil {
+			return errors.Wrapf(err, "repository: rollback failed: %v", rollbackErr)
+		}
+
+		return err
+	}
+
+	if err = tx.Commit(); err != nil {
+		return errors.Wrap(err, "repository: cannot commit transaction")
+	}
+
+	return nil
+}
+
+// savepoint runs fn in a savepoint, which is released in any case once fn has returned, after being rolled back
+// when fn failed, so that failed nested calls do not pile up savepoints in long transactions.
+func savepoint(ctx context.Context, state *txState, fn func(ctx context.Context) error) error {
+	state.savepoints++
+	name := fmt.Sprintf("sp_%d", state.savepoints)
+
+	if _, err := state.tx.ExecContext(ctx, "SAVEPOINT "+name); err != nil {
+		return errors.Wrapf(err, "repository: cannot create savepoint %s", name)
+	}
+
+	if err := fn(ctx); err != nil {
+		if _, rollbackErr := state.tx.ExecContext(ctx, "ROLLBACK TO SAVEPOINT "+name); rollbackErr != nil {
+			return errors.Wrapf(err, "repository: rollback to savepoint %s failed: %v", name, rollbackErr)
+		}
+
+		if _, releaseErr := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); releaseErr != nil {
+			return errors.Wrapf(err, "repository: release of savepoint %s failed: %v", name, releaseErr)
+		}
+
+		return err
+	}
+
+	if _, err := state.tx.ExecContext(ctx, "RELEASE SAVEPOINT "+name); err != nil {
+		return errors.Wrapf(err, "repository: cannot release savepoint %s", name)
+	}
+
+	return nil
+}
+
+// IsSerializationFailure reports whether the error is a PostgreSQL serialization failure or deadlock, after
+// which the transaction can succeed when run again.
+func IsSerializationFailure(err error) bool {
+	var pqErr *pq.Error
+	if !errors.As(err, &pqErr) {
+		return false
+	}
+
+	return pqErr.Code == errCodeSerializationFailure || pqErr.Code == errCodeDeadlockDetected
+}
+
+// IsUniqueViolation reports whether the error is a PostgreSQL unique constraint violation.
+func IsUniqueViolation(err error) bool {
+	var pqErr *pq.Error
+
+	return errors.As(err, &pqErr) && pqErr.Code == errCodeUniqueViolation
+}
@@ -0,0 +1,311 @@
+package repo
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+// recorder is a database/sql driver that logs the statements it receives. Every statement reports one
+// affected row per bound argument, and every query returns the next result given to Returns, the last one
+// being repeated.
+type recorder struct {
+	mu      sync.Mutex
+	log     []string
+	results []*recordedRows
+}
+
+func (r *recorder) Returns(columns []string, rows ...[]driver.Value) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.results = append(r.results, &recordedRows{columns: columns, rows: rows})
+}
+
+func (r *recorder) next() *recordedRows {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if len(r.results) == 0 {
+		return &recordedRows{}
+	}
+
+	result := *r.results[0]
+
+	if len(r.results) > 1 {
+		r.results = r.results[1:]
+	}
+
+	return &result
+}
+
+func (r *recorder) record(statement string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.log = append(r.log, statement)
+}
+
+func (r *recorder) Statements() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return append([]string(nil), r.log...)
+}
+
+func (r *recorder) Open(string) (driver.Conn, error) {
+	return &recordingConn{recorder: r}, nil
+}
+
+type recordingConn struct {
+	recorder *recorder
+}
+
+func (c *recordingConn) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("recorder: prepare is not supported")
+}
+
+func (c *recordingConn) Close() error {
+	return nil
+}
+
+func (c *recordingConn) Begin() (driver.Tx, error) {
+	return c.BeginTx(context.Background(), driver.TxOptions{})
+}
+
+func (c *recordingConn) BeginTx(_ context.Context, opts driver.TxOptions) (driver.Tx, error) {
+	statement := "BEGIN"
+
+	if level := sql.IsolationLevel(opts.Isolation); level != sql.LevelDefault {
+		statement += " " + strings.ToUpper(level.String())
+	}
+
+	c.recorder.record(statement)
+
+	return recordingTx{recorder: c.recorder}, nil
+}
+
+func (c *recordingConn) ExecContext(_ context.Context, query string, args []driver.NamedValue) (driver.Result, error) {
+	c.recorder.record(query)
+
+	return driver.RowsAffected(len(args)), nil
+}
+
+func (c *recordingConn) QueryContext(_ context.Context, query string, _ []driver.NamedValue) (driver.Rows, error) {
+	c.recorder.record(query)
+
+	return c.recorder.next(), nil
+}
+
+type recordedRows struct {
+	columns []string
+	rows    [][]driver.Value
+}
+
+func (r *recordedRows) Columns() []string {
+	return r.columns
+}
+
+func (r *recordedRows) Close() error {
+	return nil
+}
+
+func (r *recordedRows) Next(dest []driver.Value) error {
+	if len(r.rows) == 0 {
+		return io.EOF
+	}
+
+	copy(dest, r.rows[0])
+	r.rows = r.rows[1:]
+
+	return nil
+}
+
+type recordingTx struct {
+	recorder *recorder
+}
+
+func (t recordingTx) Commit() error {
+	t.recorder.record("COMMIT")
+
+	return nil
+}
+
+func (t recordingTx) Rollback() error {
+	t.recorder.record("ROLLBACK")
+
+	return nil
+}
+
+func newRecordingDB(t *testing.T) (*sql.DB, *recorder) {
+	t.Helper()
+
+	rec := new(recorder)
+	db := sql.OpenDB(connector{rec})
+	db.SetMaxOpenConns(1)
+
+	t.Cleanup(func() {
+		_ = db.Close()
+	})
+
+	return db, rec
+}
+
+func newRecordingUnitOfWork(t *testing.T) (UnitOfWork, *recorder) {
+	t.Helper()
+
+	db, rec := newRecordingDB(t)
+
+	return NewUnitOfWork(UnitOfWorkParams{DB: db}), rec
+}
+
+type connector struct {
+	recorder *recorder
+}
+
+func (c connector) Connect(context.Context) (driver.Conn, error) {
+	return c.recorder.Open("")
+}
+
+func (c connector) Driver() driver.Driver {
+	return c.recorder
+}
+
+func TestUnitOfWork_Do(t *testing.T) {
+	t.Parallel()
+
+	errFailed := errors.New("failed")
+	serializationFailure := &pq.Error{Code: errCodeSerializationFailure}
+
+	tests := []struct {
+		name    string
+		run     func(ctx context.Context, uow UnitOfWork) error
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Commit",
+			run: func(ctx context.Context, uow UnitOfWork) error {
+				return uow.Do(ctx, func(ctx context.Context) error {
+					_, err := Conn(ctx, nil).ExecContext(ctx, "UPDATE tenant SET name = 'a'")
+
+					return err
+				}, WithIsolation(sql.LevelSerializable))
+			},
+			want: []string{"BEGIN SERIALIZABLE", "UPDATE tenant SET name = 'a'", "COMMIT"},
+		},
+		{
+			name: "Rollback",
+			run: func(ctx context.Context, uow UnitOfWork) error {
+				return uow.Do(ctx, func(context.Context) error {
+					return errFailed
+				})
+			},
+			want:    []string{"BEGIN", "ROLLBACK"},
+			wantErr: true,
+		},
+		{
+			name: "NestedSavepoints",
+			run: func(ctx context.Context, uow UnitOfWork) error {
+				return uow.Do(ctx, func(ctx context.Context) error {
+					if err := uow.Do(ctx, func(context.Context) error { return nil }); err != nil {
+						return err
+					}
+
+					_ = uow.Do(ctx, func(context.Context) error { return errFailed })
+
+					return nil
+				})
+			},
+			want: []string{
+				"BEGIN",
+				"SAVEPOINT sp_1",
+				"RELEASE SAVEPOINT sp_1",
+				"SAVEPOINT sp_2",
+				"ROLLBACK TO SAVEPOINT sp_2",
+				"RELEASE SAVEPOINT sp_2",
+				"COMMIT",
+			},
+		},
+		{
+			name: "NestedWithSameIsolation",
+			run: func(ctx context.Context, uow UnitOfWork) error {
+				return uow.Do(ctx, func(ctx context.Context) error {
+					return uow.Do(ctx, func(context.Context) error {
+						return nil
+					}, WithIsolation(sql.LevelSerializable))
+				}, WithIsolation(sql.LevelSerializable))
+			},
+			want: []string{"BEGIN SERIALIZABLE", "SAVEPOINT sp_1", "RELEASE SAVEPOINT sp_1", "COMMIT"},
+		},
+		{
+			name: "NestedWithOtherIsolation",
+			run: func(ctx context.Context, uow UnitOfWork) error {
+				return uow.Do(ctx, func(ctx context.Context) error {
+					return uow.Do(ctx, func(context.Context) error {
+						return nil
+					}, WithIsolation(sql.LevelSerializable))
+				})
+			},
+			want:    []string{"BEGIN", "ROLLBACK"},
+			wantErr: true,
+		},
+		{
+			name: "RetryOnSerializationFailure",
+			run: func(ctx context.Context, uow UnitOfWork) error {
+				attempts := 0
+
+				return uow.Do(ctx, func(context.Context) error {
+					attempts++
+					if attempts < 3 {
+						return errors.Wrap(serializationFailure, "repository: failed to save tenant")
+					}
+
+					return nil
+				})
+			},
+			want: []string{"BEGIN", "ROLLBACK", "BEGIN", "ROLLBACK", "BEGIN", "COMMIT"},
+		},
+		{
+			name: "GiveUpAfterRetries",
+			run: func(ctx context.Context, uow UnitOfWork) error {
+				return uow.Do(ctx, func(context.Context) error {
+					return serializationFailure
+				}, WithRetries(1))
+			},
+			want:    []string{"BEGIN", "ROLLBACK", "BEGIN", "ROLLBACK"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			uow, rec := newRecordingUnitOfWork(t)
+
+			gotErr := tt.run(context.Background(), uow)
+			if tt.wantErr && gotErr == nil {
+				t.Fatal("expected an error")
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, rec.Statements(), false, nil)
+		})
+	}
+}
+
+func TestConn(t *testing.T) {
+	t.Parallel()
+
+	db := new(sql.DB)
+
+	fixture.ExpectationsWereMet[Executor](t, db, Conn(context.Background(), db), false, nil)
+}
@@ -0,0 +1,169 @@
+package repo
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+)
+
+type UpdateBuilder[T any] struct {
+	updateClause    string
+	setClause       string
+	setArgs         []any
+	where           whereBuilder
+	returningClause string
+	placeholder     PlaceholderFormat
+	err             error
+}
+
+func NewUpdateBuilder[T any]() *UpdateBuilder[T] {
+	return &UpdateBuilder[T]{}
+}
+
+// Placeholder sets the format of the bind parameters in the built query. Defaults to Question.
+func (b *UpdateBuilder[T]) Placeholder(format PlaceholderFormat) *UpdateBuilder[T] {
+	b.placeholder = format
+
+	return b
+}
+
+func (b *UpdateBuilder[T]) Update(table string) *UpdateBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	if table == "" {
+		b.err = errors.New("repository: target table is required")
+
+		return b
+	}
+
+	b.updateClause = "UPDATE " + table
+
+	return b
+}
+
+// Set assigns the given columns from the entity, or all of its tagged fields when no column is given.
+func (b *UpdateBuilder[T]) Set(values *T, columns ...string) *UpdateBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	setters, err := ToSetters(values)
+	if err != nil {
+		b.err = errors.Wrap(err, "repository: failed to convert values to setters")
+
+		return b
+	}
+
+	if len(columns) > 0 {
+		if setters, err = PickSetters(setters, columns...); err != nil {
+			b.err = err
+
+			return b
+		}
+	}
+
+	return b.SetValues(setters...)
+}
+
+func (b *UpdateBuilder[T]) SetValues(values ...Setter) *UpdateBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	if len(values) == 0 {
+		b.err = errors.New("repository: values are required")
+
+		return b
+	}
+
+	b.setClause, b.setArgs, b.err = stringifySetters(values)
+
+	return b
+}
+
+// Where adds a filter or a filter group to the WHERE clause, combined with the previous ones with AND.
+func (b *UpdateBuilder[T]) Where(condition domain.Condition, optAlias ...string) *UpdateBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	b.err = b.where.add(condition, optAlias...)
+
+	return b
+}
+
+func (b *UpdateBuilder[T]) Returning(columns ...string) *UpdateBuilder[T] {
+	if b.err != nil {
+		return b
+	}
+
+	b.returningClause, b.err = stringifyReturning(columns)
+
+	return b
+}
+
+// build refuses statements without a WHERE clause, which would update every row of the table.
+func (b *UpdateBuilder[T]) build() (string, error) {
+	if b.err != nil {
+		return "", b.err
+	}
+
+	if b.updateClause == "" {
+		return "", errors.New("repository: update clause is required")
+	}
+
+	if b.setClause == "" {
+		return "", errors.New("repository: set clause is required")
+	}
+
+	if b.where.clause.Len() == 0 {
+		return "", errors.New("repository: where clause is required")
+	}
+
+	return placeholderOrDefault(b.placeholder).Replace(b.updateClause +
+		" SET " + b.setClause +
+		b.where.clause.String() +
+		b.returningClause)
+}
+
+func (b *UpdateBuilder[T]) String() (string, error) {
+	return b.build()
+}
+
+func (b *UpdateBuilder[T]) args() []any {
+	return append(append(make([]any, 0, len(b.setArgs)+len(b.where.args)), b.setArgs...), b.where.args...)
+}
+
+// Exec runs the update and returns the number of updated rows.
+func (b *UpdateBuilder[T]) Exec(ctx context.Context, db Executor) (int64, error) {
+	query, err := b.build()
+	if err != nil {
+		return -1, err
+	}
+
+	return execStatement(ctx, db, query, b.args())
+}
+
+// Query runs the update and scans the row of its RETURNING clause. It returns sql.ErrNoRows when no row
+// matched.
+func (b *UpdateBuilder[T]) Query(ctx context.Context, db Executor, scanners ...Scanner[T]) (*T, error) {
+	query, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+
+	return queryOneStatement(ctx, db, query, b.args(), scannerOf(scanners))
+}
+
+// QueryAll runs the update and scans every row of its RETURNING clause.
+func (b *UpdateBuilder[T]) QueryAll(ctx context.Context, db Executor, scanners ...Scanner[T]) ([]*T, error) {
+	query, err := b.build()
+	if err != nil {
+		return nil, err
+	}
+
+	return queryStatement(ctx, db, query, b.args(), scannerOf(scanners))
+}
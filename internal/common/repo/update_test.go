@@ -0,0 +1,117 @@
+package repo
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+func TestUpdateBuilder_Build(t *testing.T) {
+	t.Parallel()
+
+	user := &testUser{ID: 1, Name: "An", Email: "an@vnworkday.com"}
+
+	type result struct {
+		Query string
+		Args  []any
+	}
+
+	tests := []struct {
+		name    string
+		setup   func(b *UpdateBuilder[testUser])
+		want    result
+		wantErr bool
+	}{
+		{
+			name: "Build With Picked Columns",
+			setup: func(b *UpdateBuilder[testUser]) {
+				b.Update("users").
+					Set(user, "name", "email").
+					Where(domain.Filter{Field: "id", Op: domain.Eq, Value: 1})
+			},
+			want: result{
+				Query: "UPDATE users SET name = ?, email = ? WHERE id = ?",
+				Args:  []any{"An", "an@vnworkday.com", 1},
+			},
+		},
+		{
+			name: "Build With Filter Group And Returning",
+			setup: func(b *UpdateBuilder[testUser]) {
+				b.Placeholder(Dollar).
+					Update("users").
+					SetValues(Setter{Field: "name", Value: "Bình"}).
+					Where(domain.AnyOf(
+						domain.Filter{Field: "id", Op: domain.In, Value: []int{1, 2}},
+						domain.Filter{Field: "email", Op: domain.Null},
+					)).
+					Where(domain.Filter{Field: "name", Op: domain.Ne, Value: "Bình"}).
+					Returning("id", "name")
+			},
+			want: result{
+				Query: "UPDATE users SET name = $1 WHERE (id IN ($2, $3) OR email IS NULL) AND name <> $4 " +
+					"RETURNING id, name",
+				Args: []any{"Bình", 1, 2, "Bình"},
+			},
+		},
+		{
+			name: "Build Without Where Clause",
+			setup: func(b *UpdateBuilder[testUser]) {
+				b.Update("users").Set(user, "name")
+			},
+			wantErr: true,
+		},
+		{
+			name: "Build Without Set Clause",
+			setup: func(b *UpdateBuilder[testUser]) {
+				b.Update("users").Where(domain.Filter{Field: "id", Op: domain.Eq, Value: 1})
+			},
+			wantErr: true,
+		},
+		{
+			name: "Build Without Table",
+			setup: func(b *UpdateBuilder[testUser]) {
+				b.Set(user, "name").Where(domain.Filter{Field: "id", Op: domain.Eq, Value: 1})
+			},
+			wantErr: true,
+		},
+		{
+			name: "Build With Invalid Filter",
+			setup: func(b *UpdateBuilder[testUser]) {
+				b.Update("users").Set(user, "name").Where(domain.Filter{Field: "id", Op: domain.Op(999), Value: 1})
+			},
+			wantErr: true,
+		},
+		{
+			name: "Build With Empty Returning Column",
+			setup: func(b *UpdateBuilder[testUser]) {
+				b.Update("users").
+					Set(user, "name").
+					Where(domain.Filter{Field: "id", Op: domain.Eq, Value: 1}).
+					Returning("")
+			},
+			wantErr: true,
+		},
+		{
+			name: "Build With Preceding Error",
+			setup: func(b *UpdateBuilder[testUser]) {
+				b.err = errors.New("forced error")
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			builder := NewUpdateBuilder[testUser]()
+			tt.setup(builder)
+
+			query, gotErr := builder.String()
+
+			fixture.ExpectationsWereMet(t, tt.want, result{Query: query, Args: builder.args()}, tt.wantErr, gotErr)
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package repo
+
+import "fmt"
+
+// ReasonVersionMismatch is the reason of the error that Repository.Update returns when the row has been changed
+// since the version of the entity.
+const ReasonVersionMismatch = "VERSION_MISMATCH"
+
+// ConflictError reports that a row has been changed since the version that an update was read at, so that
+// applying the update would overwrite someone else's write.
+type ConflictError struct {
+	Table   string
+	Key     any
+	Version any
+}
+
+func (e *ConflictError) Error() string {
+	return fmt.Sprintf("repository: %s %v has been modified since version %v", e.Table, e.Key, e.Version)
+}
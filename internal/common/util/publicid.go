@@ -0,0 +1,51 @@
+package util
+
+import (
+	"crypto/rand"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// publicIDAlphabet is Crockford's base32, whose 32 symbols map evenly onto random bytes and leave out the
+	// letters that read like digits.
+	publicIDAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	publicIDLength   = 16
+)
+
+// NewPublicID returns a random identifier to show to people in place of an internal key, such as
+// "tn_7KQ2M9XD4HV0RB3C" for the prefix "tn_". Its 80 random bits make it impractical to guess or enumerate.
+func NewPublicID(prefix string) (string, error) {
+	random := make([]byte, publicIDLength)
+	if _, err := rand.Read(random); err != nil {
+		return "", errors.Wrap(err, "util: cannot generate public id")
+	}
+
+	var id strings.Builder
+
+	id.Grow(len(prefix) + publicIDLength)
+	id.WriteString(prefix)
+
+	for _, b := range random {
+		id.WriteByte(publicIDAlphabet[int(b)%len(publicIDAlphabet)])
+	}
+
+	return id.String(), nil
+}
+
+// IsPublicID reports whether the value has the form of the identifiers that NewPublicID returns for the prefix.
+func IsPublicID(prefix string, value string) bool {
+	random, ok := strings.CutPrefix(value, prefix)
+	if !ok || len(random) != publicIDLength {
+		return false
+	}
+
+	for _, r := range random {
+		if !strings.ContainsRune(publicIDAlphabet, r) {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+func TestNewPublicID(t *testing.T) {
+	t.Parallel()
+
+	first, err := NewPublicID("tn_")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := NewPublicID("tn_")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := []bool{IsPublicID("tn_", first), IsPublicID("tn_", second), first == second}
+
+	fixture.ExpectationsWereMet(t, []bool{true, true, false}, got, false, nil)
+}
+
+func TestIsPublicID(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input string
+		want  bool
+	}{
+		{"WithValidID", "tn_7KQ2M9XD4HV0RB3C", true},
+		{"WithOtherPrefix", "ac_7KQ2M9XD4HV0RB3C", false},
+		{"WithoutPrefix", "7KQ2M9XD4HV0RB3C", false},
+		{"WithShortID", "tn_7KQ2M9XD", false},
+		{"WithLowercase", "tn_7kq2m9xd4hv0rb3c", false},
+		{"WithAmbiguousLetter", "tn_7KQ2M9XD4HV0RB3I", false},
+		{"WithUUID", "0190b0b0-7d6c-7b3e-8f4a-2c1d9e8f7a6b", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			fixture.ExpectationsWereMet(t, tt.want, IsPublicID("tn_", tt.input), false, nil)
+		})
+	}
+}
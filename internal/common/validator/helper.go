@@ -1,6 +1,10 @@
 package validator
 
-import "context"
+import (
+	"context"
+
+	"github.com/vnworkday/account/internal/common/errs"
+)
 
 // ValidationFunc is the type for validation functions.
 type ValidationFunc func(ctx context.Context, request any) error
@@ -14,3 +18,54 @@ func Validate(ctx context.Context, request any, validations ...ValidationFunc) e
 
 	return nil
 }
+
+// ValidateAll runs every validation, unlike Validate which stops at the first failure, and merges their failures
+// into one error that lists all of their violations. A failure without a kind, such as a database error, is
+// returned as is, since the request could not be validated.
+func ValidateAll(ctx context.Context, request any, validations ...ValidationFunc) error {
+	failures := make([]*errs.Error, 0)
+
+	for _, validation := range validations {
+		err := validation(ctx, request)
+		if err == nil {
+			continue
+		}
+
+		failure := errs.As(err)
+		if failure == nil {
+			return err
+		}
+
+		failures = append(failures, failure)
+	}
+
+	return merge(failures)
+}
+
+// merge keeps a single failure as it is. Several failures make an error of their common kind, or an
+// InvalidArgument when their kinds differ, whose violations are those of all the failures.
+func merge(failures []*errs.Error) error {
+	switch len(failures) {
+	case 0:
+		return nil
+	case 1:
+		return failures[0]
+	}
+
+	merged := errs.NewInvalidArgument()
+	merged.Kind = failures[0].Kind
+
+	for _, failure := range failures {
+		if failure.Kind != merged.Kind {
+			merged.Kind = errs.InvalidArgument
+		}
+
+		if len(failure.Violations) == 0 {
+			merged.Violations = append(merged.Violations, errs.Violation{Description: failure.Message})
+		}
+
+		merged.Violations = append(merged.Violations, failure.Violations...)
+	}
+
+	return merged
+}
@@ -0,0 +1,108 @@
+package validator
+
+import (
+	"context"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+func fails(err error) ValidationFunc {
+	return func(context.Context, any) error {
+		return err
+	}
+}
+
+func TestValidateAll(t *testing.T) {
+	t.Parallel()
+
+	nameTaken := func() error {
+		return errs.NewAlreadyExists("tenant", "").
+			WithViolations(errs.Violation{Field: "name", Description: "is already taken"})
+	}
+	domainTaken := func() error {
+		return errs.NewAlreadyExists("tenant", "").
+			WithViolations(errs.Violation{Field: "domain", Description: "is already taken"})
+	}
+
+	type result struct {
+		Kind       errs.Kind
+		Violations []errs.Violation
+	}
+
+	tests := []struct {
+		name        string
+		validations []ValidationFunc
+		want        *result
+		wantErr     bool
+	}{
+		{
+			name:        "NoFailure",
+			validations: []ValidationFunc{fails(nil), fails(nil)},
+			want:        nil,
+		},
+		{
+			name:        "SingleFailure",
+			validations: []ValidationFunc{fails(nil), fails(nameTaken())},
+			want: &result{
+				Kind:       errs.AlreadyExists,
+				Violations: []errs.Violation{{Field: "name", Description: "is already taken"}},
+			},
+		},
+		{
+			name:        "FailuresOfSameKind",
+			validations: []ValidationFunc{fails(nameTaken()), fails(domainTaken())},
+			want: &result{
+				Kind: errs.AlreadyExists,
+				Violations: []errs.Violation{
+					{Field: "name", Description: "is already taken"},
+					{Field: "domain", Description: "is already taken"},
+				},
+			},
+		},
+		{
+			name: "FailuresOfDifferentKinds",
+			validations: []ValidationFunc{
+				fails(nameTaken()),
+				fails(errs.NewInvalidArgument(errs.Violation{Field: "timezone", Description: "is unknown"})),
+				fails(errs.New(errs.FailedPrecondition, "tenant is suspended")),
+			},
+			want: &result{
+				Kind: errs.InvalidArgument,
+				Violations: []errs.Violation{
+					{Field: "name", Description: "is already taken"},
+					{Field: "timezone", Description: "is unknown"},
+					{Description: "tenant is suspended"},
+				},
+			},
+		},
+		{
+			name:        "FailureWithoutKind",
+			validations: []ValidationFunc{fails(nameTaken()), fails(errors.New("database is down"))},
+			wantErr:     true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotErr := ValidateAll(context.Background(), nil, tt.validations...)
+			if tt.wantErr {
+				fixture.ExpectationsWereMet(t, errs.Unknown, errs.KindOf(gotErr), false, nil)
+
+				return
+			}
+
+			var got *result
+
+			if typed := errs.As(gotErr); typed != nil {
+				got = &result{Kind: typed.Kind, Violations: typed.Violations}
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, false, nil)
+		})
+	}
+}
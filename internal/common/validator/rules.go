@@ -0,0 +1,411 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/errs"
+)
+
+const (
+	ruleTag           = "validate"
+	maxHostnameLength = 253
+)
+
+var (
+	timeType = reflect.TypeFor[time.Time]()
+
+	hostnameLabel = regexp.MustCompile(`^[a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?$`)
+
+	// structRules caches, per struct type, the rules of its fields or the error of its tags.
+	structRules sync.Map
+)
+
+// check is a rule that the value of a field must follow, and the violation that reports a value that does not.
+type check struct {
+	valid  func(value reflect.Value) bool
+	code   string
+	params map[string]string
+}
+
+type fieldRules struct {
+	index    int
+	name     string
+	required bool
+	checks   []check
+	// nested is set for structs and slices of structs, whose own fields have rules.
+	nested bool
+}
+
+type typeRules struct {
+	fields []fieldRules
+	err    error
+}
+
+// Struct checks the fields of a struct against the rules of their validate tags, and returns an InvalidArgument
+// error listing every field that breaks one, or nil. Only the given top-level fields are checked, named as in
+// their json tags, or all of them when none is given. The rules are separated by commas, and the violations of
+// each one have the code in parentheses, see i18n:
+//
+//   - required (REQUIRED): the value must not be zero. The other rules skip zero values, so that fields are
+//     optional.
+//   - min=N, max=N (MIN_LENGTH, MIN_ITEMS, MIN_VALUE and their MAX_ counterparts): bounds the length of strings
+//     in characters, the number of items of slices and maps, or the value of numbers.
+//   - enum=A|B|C (ENUM): the value must be one of the listed ones.
+//   - timezone, hostname, uuid (TIMEZONE, HOSTNAME, UUID): the string must be an IANA time zone, a hostname or
+//     a UUID.
+//   - regex=PATTERN (PATTERN): the string must match the pattern, which takes the rest of the tag, commas
+//     included, so this rule comes last.
+//
+// Fields that are structs, or slices of structs, are checked as well and their violations are named by their
+// path, such as "owner.email" or "members[2].role". The tags are parsed once per type, and an invalid tag
+// is reported as an error without a kind.
+//
+// Example:
+//
+//	type CreateTenantRequest struct {
+//		Name     string `json:"name"     validate:"required,max=255"`
+//		Timezone string `json:"timezone" validate:"required,timezone"`
+//	}
+func Struct(request any, fields ...string) error {
+	value := reflect.Indirect(reflect.ValueOf(request))
+	if value.Kind() != reflect.Struct {
+		return errors.Errorf("validator: cannot validate %s, a struct is required", value.Kind())
+	}
+
+	violations, err := validateStruct(value, "", fields)
+	if err != nil {
+		return err
+	}
+
+	if len(violations) > 0 {
+		return errs.NewInvalidArgument(violations...)
+	}
+
+	return nil
+}
+
+// Rules is Struct as a ValidationFunc, to combine the rules of the request with other validations.
+func Rules(fields ...string) ValidationFunc {
+	return func(_ context.Context, request any) error {
+		return Struct(request, fields...)
+	}
+}
+
+func validateStruct(value reflect.Value, prefix string, only []string) ([]errs.Violation, error) {
+	rules := rulesOf(value.Type())
+	if rules.err != nil {
+		return nil, rules.err
+	}
+
+	violations := make([]errs.Violation, 0)
+
+	for _, field := range rules.fields {
+		if len(only) > 0 && !slices.Contains(only, field.name) {
+			continue
+		}
+
+		nested, err := validateField(value.Field(field.index), prefix+field.name, field)
+		if err != nil {
+			return nil, err
+		}
+
+		violations = append(violations, nested...)
+	}
+
+	return violations, nil
+}
+
+func validateField(value reflect.Value, path string, field fieldRules) ([]errs.Violation, error) {
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			value = reflect.Zero(value.Type().Elem())
+
+			break
+		}
+
+		value = value.Elem()
+	}
+
+	if value.IsZero() {
+		if field.required {
+			return []errs.Violation{errs.NewViolation(path, "REQUIRED", nil)}, nil
+		}
+
+		return nil, nil
+	}
+
+	violations := make([]errs.Violation, 0)
+
+	for _, check := range field.checks {
+		if !check.valid(value) {
+			violations = append(violations, errs.NewViolation(path, check.code, check.params))
+		}
+	}
+
+	if !field.nested {
+		return violations, nil
+	}
+
+	if value.Kind() == reflect.Struct {
+		nested, err := validateStruct(value, path+".", nil)
+
+		return append(violations, nested...), err
+	}
+
+	for idx := range value.Len() {
+		item := reflect.Indirect(value.Index(idx))
+		if item.Kind() != reflect.Struct {
+			continue
+		}
+
+		nested, err := validateStruct(item, fmt.Sprintf("%s[%d].", path, idx), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		violations = append(violations, nested...)
+	}
+
+	return violations, nil
+}
+
+func rulesOf(typ reflect.Type) *typeRules {
+	if cached, ok := structRules.Load(typ); ok {
+		return cached.(*typeRules) //nolint:forcetypeassert
+	}
+
+	rules := new(typeRules)
+	rules.fields, rules.err = parseStruct(typ)
+
+	cached, _ := structRules.LoadOrStore(typ, rules)
+
+	return cached.(*typeRules) //nolint:forcetypeassert
+}
+
+func parseStruct(typ reflect.Type) ([]fieldRules, error) {
+	fields := make([]fieldRules, 0, typ.NumField())
+
+	for i := range typ.NumField() {
+		field := typ.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		if name == "" {
+			name = field.Name
+		}
+
+		rules, err := parseRules(field.Tag.Get(ruleTag), field.Type)
+		if err != nil {
+			return nil, errors.Wrapf(err, "validator: invalid rules of field %s of %s", field.Name, typ)
+		}
+
+		rules.index = i
+		rules.name = name
+		rules.nested = hasNestedRules(field.Type)
+
+		if rules.required || len(rules.checks) > 0 || rules.nested {
+			fields = append(fields, rules)
+		}
+	}
+
+	return fields, nil
+}
+
+func hasNestedRules(typ reflect.Type) bool {
+	typ = indirectType(typ)
+
+	if typ.Kind() == reflect.Slice || typ.Kind() == reflect.Array {
+		typ = indirectType(typ.Elem())
+	}
+
+	return typ.Kind() == reflect.Struct && typ != timeType
+}
+
+func parseRules(tag string, typ reflect.Type) (fieldRules, error) {
+	var rules fieldRules
+
+	typ = indirectType(typ)
+
+	for tag != "" {
+		var option string
+
+		if strings.HasPrefix(tag, "regex=") {
+			option, tag = tag, ""
+		} else {
+			option, tag, _ = strings.Cut(tag, ",")
+		}
+
+		name, arg, _ := strings.Cut(strings.TrimSpace(option), "=")
+		if name == "required" {
+			rules.required = true
+
+			continue
+		}
+
+		check, err := newCheck(name, arg, typ)
+		if err != nil {
+			return rules, err
+		}
+
+		rules.checks = append(rules.checks, check)
+	}
+
+	return rules, nil
+}
+
+func newCheck(name string, arg string, typ reflect.Type) (check, error) {
+	switch name {
+	case "min", "max":
+		return newBoundCheck(name, arg, typ)
+	case "enum":
+		return newEnumCheck(arg)
+	}
+
+	if typ.Kind() != reflect.String {
+		return check{}, errors.Errorf("rule %s only applies to strings, got %s", name, typ)
+	}
+
+	switch name {
+	case "regex":
+		pattern, err := regexp.Compile(arg)
+		if err != nil {
+			return check{}, errors.Wrapf(err, "invalid pattern %s", arg)
+		}
+
+		return stringCheck(pattern.MatchString, "PATTERN", map[string]string{"pattern": arg}), nil
+	case "timezone":
+		return stringCheck(isTimezone, "TIMEZONE", nil), nil
+	case "hostname":
+		return stringCheck(isHostname, "HOSTNAME", nil), nil
+	case "uuid":
+		return stringCheck(isUUID, "UUID", nil), nil
+	default:
+		return check{}, errors.Errorf("unknown rule %s", name)
+	}
+}
+
+// newBoundCheck bounds the length of strings, the size of collections or the value of numbers, whose violations
+// have the codes MIN_LENGTH, MIN_ITEMS and MIN_VALUE, or their MAX_ counterparts.
+func newBoundCheck(name string, arg string, typ reflect.Type) (check, error) {
+	bound, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return check{}, errors.Errorf("bound %q is not an integer", arg)
+	}
+
+	var measure func(value reflect.Value) int64
+	var unit string
+
+	switch typ.Kind() {
+	case reflect.String:
+		measure, unit = func(value reflect.Value) int64 {
+			return int64(utf8.RuneCountInString(value.String()))
+		}, "LENGTH"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		measure, unit = func(value reflect.Value) int64 {
+			return int64(value.Len())
+		}, "ITEMS"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		measure, unit = reflect.Value.Int, "VALUE"
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		measure, unit = func(value reflect.Value) int64 {
+			return int64(min(value.Uint(), math.MaxInt64))
+		}, "VALUE"
+	default:
+		return check{}, errors.Errorf("rules min and max do not apply to %s", typ)
+	}
+
+	lower := name == "min"
+
+	return check{
+		valid: func(value reflect.Value) bool {
+			if lower {
+				return measure(value) >= bound
+			}
+
+			return measure(value) <= bound
+		},
+		code:   strings.ToUpper(name) + "_" + unit,
+		params: map[string]string{name: arg},
+	}, nil
+}
+
+func newEnumCheck(arg string) (check, error) {
+	values := strings.Split(arg, "|")
+	if arg == "" {
+		return check{}, errors.New("rule enum needs at least one value")
+	}
+
+	return check{
+		valid: func(value reflect.Value) bool {
+			return slices.Contains(values, fmt.Sprint(value.Interface()))
+		},
+		code:   "ENUM",
+		params: map[string]string{"values": strings.Join(values, ", ")},
+	}, nil
+}
+
+func stringCheck(valid func(string) bool, code string, params map[string]string) check {
+	return check{
+		valid: func(value reflect.Value) bool {
+			return valid(value.String())
+		},
+		code:   code,
+		params: params,
+	}
+}
+
+func isTimezone(name string) bool {
+	if name == "Local" {
+		return false
+	}
+
+	_, err := time.LoadLocation(name)
+
+	return err == nil
+}
+
+func isHostname(name string) bool {
+	if len(name) > maxHostnameLength {
+		return false
+	}
+
+	for _, label := range strings.Split(strings.TrimSuffix(name, "."), ".") {
+		if !hostnameLabel.MatchString(label) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isUUID(value string) bool {
+	_, err := uuid.Parse(value)
+
+	return err == nil
+}
+
+func indirectType(typ reflect.Type) reflect.Type {
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+
+	return typ
+}
@@ -0,0 +1,184 @@
+package validator
+
+import (
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+type testMember struct {
+	Email string `json:"email" validate:"required,regex=^[^@,]+@[^@,]+$"`
+	Role  int    `json:"role"  validate:"enum=1|2"`
+}
+
+type testOwner struct {
+	ID string `json:"id" validate:"required,uuid"`
+}
+
+type testRequest struct {
+	Name     string       `json:"name"     validate:"required,min=2,max=5"`
+	Domain   string       `json:"domain"   validate:"hostname"`
+	Timezone string       `json:"timezone" validate:"timezone"`
+	Seats    *int         `json:"seats"    validate:"required,max=10"`
+	Tags     []string     `json:"tags"     validate:"max=2"`
+	Owner    testOwner    `json:"owner"`
+	Members  []testMember `json:"members"`
+	Internal string       `json:"-"        validate:"required"`
+}
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+
+	seats, tooManySeats := 3, 11
+
+	valid := func() testRequest {
+		return testRequest{
+			Name:     "Công",
+			Domain:   "acme.vnworkday.com",
+			Timezone: "Asia/Ho_Chi_Minh",
+			Seats:    &seats,
+			Tags:     []string{"a"},
+			Owner:    testOwner{ID: "a0b1c2d3-e4f5-4a6b-8c7d-9e0f1a2b3c4d"},
+			Members:  []testMember{{Email: "an@acme.vn", Role: 1}},
+		}
+	}
+
+	tests := []struct {
+		name   string
+		input  func() testRequest
+		fields []string
+		want   []errs.Violation
+	}{
+		{
+			name:  "Valid",
+			input: valid,
+			want:  nil,
+		},
+		{
+			name: "Required",
+			input: func() testRequest {
+				return testRequest{}
+			},
+			want: []errs.Violation{
+				errs.NewViolation("name", "REQUIRED", nil),
+				errs.NewViolation("seats", "REQUIRED", nil),
+			},
+		},
+		{
+			name: "Bounds",
+			input: func() testRequest {
+				request := valid()
+				request.Name = "Công ty"
+				request.Seats = &tooManySeats
+				request.Tags = []string{"a", "b", "c"}
+
+				return request
+			},
+			want: []errs.Violation{
+				errs.NewViolation("name", "MAX_LENGTH", map[string]string{"max": "5"}),
+				errs.NewViolation("seats", "MAX_VALUE", map[string]string{"max": "10"}),
+				errs.NewViolation("tags", "MAX_ITEMS", map[string]string{"max": "2"}),
+			},
+		},
+		{
+			name: "Formats",
+			input: func() testRequest {
+				request := valid()
+				request.Domain = "-acme.com"
+				request.Timezone = "Asia/Saigon City"
+
+				return request
+			},
+			want: []errs.Violation{
+				errs.NewViolation("domain", "HOSTNAME", nil),
+				errs.NewViolation("timezone", "TIMEZONE", nil),
+			},
+		},
+		{
+			name: "NestedPaths",
+			input: func() testRequest {
+				request := valid()
+				request.Owner.ID = "42"
+				request.Members = append(request.Members, testMember{Email: "an", Role: 3})
+
+				return request
+			},
+			want: []errs.Violation{
+				errs.NewViolation("owner.id", "UUID", nil),
+				errs.NewViolation("members[1].email", "PATTERN", map[string]string{"pattern": "^[^@,]+@[^@,]+$"}),
+				errs.NewViolation("members[1].role", "ENUM", map[string]string{"values": "1, 2"}),
+			},
+		},
+		{
+			name: "OnlyGivenFields",
+			input: func() testRequest {
+				request := valid()
+				request.Name = "C"
+				request.Domain = "-acme.com"
+
+				return request
+			},
+			fields: []string{"domain"},
+			want: []errs.Violation{
+				errs.NewViolation("domain", "HOSTNAME", nil),
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []errs.Violation
+
+			input := tt.input()
+			gotErr := Struct(&input, tt.fields...)
+
+			if typed := errs.As(gotErr); typed != nil {
+				got = typed.Violations
+			} else if gotErr != nil {
+				t.Fatal(gotErr)
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, false, nil)
+		})
+	}
+}
+
+func TestStruct_InvalidTags(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name  string
+		input any
+	}{
+		{name: "UnknownRule", input: struct {
+			Name string `validate:"email"`
+		}{}},
+		{name: "StringRuleOnNumber", input: struct {
+			Count int `validate:"uuid"`
+		}{}},
+		{name: "InvalidBound", input: struct {
+			Name string `validate:"max=ten"`
+		}{}},
+		{name: "InvalidPattern", input: struct {
+			Name string `validate:"regex=("`
+		}{}},
+		{name: "NotAStruct", input: "tenant"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			gotErr := Struct(tt.input)
+
+			fixture.ExpectationsWereMet(t, errs.Unknown, errs.KindOf(gotErr), false, nil)
+
+			if gotErr == nil {
+				t.Fatal("expected an error")
+			}
+		})
+	}
+}
@@ -1,9 +1,34 @@
 package conf
 
 import (
+	"time"
+
 	"github.com/vnworkday/config"
 )
 
+const (
+	defaultGRPCAddr             = ":50051"
+	defaultGRPCMaxRecvMsgSize   = 4 << 20
+	defaultGRPCMaxSendMsgSize   = 4 << 20
+	defaultGRPCKeepaliveTime    = 2 * time.Hour
+	defaultGRPCKeepaliveTimeout = 20 * time.Second
+	defaultGRPCKeepaliveMinTime = 5 * time.Minute
+	defaultGRPCShutdownTimeout  = 15 * time.Second
+	defaultGRPCHealthInterval   = 10 * time.Second
+
+	defaultHTTPAddr              = ":8080"
+	defaultHTTPReadHeaderTimeout = 10 * time.Second
+	defaultHTTPShutdownTimeout   = 15 * time.Second
+
+	defaultListPageSize    = 20
+	defaultListMaxPageSize = 100
+
+	defaultLocale = "vi"
+
+	defaultTenantDeletionGracePeriod = 30 * 24 * time.Hour
+	defaultTenantPurgeInterval       = time.Hour
+)
+
 type Conf struct {
 	ServiceName string `config:"service_name"`
 
@@ -13,8 +38,113 @@ type Conf struct {
 	DBUser   string `config:"db_user"`
 	DBPass   string `config:"db_pass"`
 	DBSchema string `config:"db_schema"`
+
+	GRPCAddr              string        `config:"grpc_addr"`
+	GRPCMaxRecvMsgSize    int           `config:"grpc_max_recv_msg_size"`
+	GRPCMaxSendMsgSize    int           `config:"grpc_max_send_msg_size"`
+	GRPCKeepaliveTime     time.Duration `config:"grpc_keepalive_time"`
+	GRPCKeepaliveTimeout  time.Duration `config:"grpc_keepalive_timeout"`
+	GRPCKeepaliveMinTime  time.Duration `config:"grpc_keepalive_min_time"`
+	GRPCMaxConnectionIdle time.Duration `config:"grpc_max_connection_idle"`
+	GRPCShutdownTimeout   time.Duration `config:"grpc_shutdown_timeout"`
+
+	GRPCHealthCheckInterval time.Duration `config:"grpc_health_check_interval"`
+
+	HTTPAddr              string        `config:"http_addr"`
+	HTTPReadHeaderTimeout time.Duration `config:"http_read_header_timeout"`
+	HTTPShutdownTimeout   time.Duration `config:"http_shutdown_timeout"`
+
+	PageTokenSecret string `config:"page_token_secret"`
+	ListPageSize    int    `config:"list_page_size"`
+	ListMaxPageSize int    `config:"list_max_page_size"`
+
+	// DefaultLocale is the locale of the messages sent to clients whose Accept-Language names no supported one,
+	// whatever their tenant, see package i18n.
+	DefaultLocale string `config:"default_locale"`
+
+	// TenantDeletionGracePeriod is how long a deleted tenant can be restored before it is purged.
+	TenantDeletionGracePeriod time.Duration `config:"tenant_deletion_grace_period"`
+	TenantPurgeInterval       time.Duration `config:"tenant_purge_interval"`
+	// TenantDataTables lists, separated by spaces, the tables that keep rows for a tenant and their column that
+	// holds its id, such as "membership.tenant_id account.tenant_id". Their rows are deleted in this order when
+	// the tenant is purged.
+	TenantDataTables []string `config:"tenant_data_tables"`
 }
 
 func New() (*Conf, error) {
-	return config.LoadConfig[Conf](new(Conf))
+	cfg, err := config.LoadConfig[Conf](new(Conf))
+	if err != nil {
+		return nil, err
+	}
+
+	applyDefaults(cfg)
+
+	return cfg, nil
+}
+
+// applyDefaults fills in the settings that are optional in the environment.
+func applyDefaults(cfg *Conf) {
+	if cfg.GRPCAddr == "" {
+		cfg.GRPCAddr = defaultGRPCAddr
+	}
+
+	if cfg.GRPCMaxRecvMsgSize <= 0 {
+		cfg.GRPCMaxRecvMsgSize = defaultGRPCMaxRecvMsgSize
+	}
+
+	if cfg.GRPCMaxSendMsgSize <= 0 {
+		cfg.GRPCMaxSendMsgSize = defaultGRPCMaxSendMsgSize
+	}
+
+	if cfg.GRPCKeepaliveTime <= 0 {
+		cfg.GRPCKeepaliveTime = defaultGRPCKeepaliveTime
+	}
+
+	if cfg.GRPCKeepaliveTimeout <= 0 {
+		cfg.GRPCKeepaliveTimeout = defaultGRPCKeepaliveTimeout
+	}
+
+	if cfg.GRPCKeepaliveMinTime <= 0 {
+		cfg.GRPCKeepaliveMinTime = defaultGRPCKeepaliveMinTime
+	}
+
+	if cfg.GRPCShutdownTimeout <= 0 {
+		cfg.GRPCShutdownTimeout = defaultGRPCShutdownTimeout
+	}
+
+	if cfg.GRPCHealthCheckInterval <= 0 {
+		cfg.GRPCHealthCheckInterval = defaultGRPCHealthInterval
+	}
+
+	if cfg.HTTPAddr == "" {
+		cfg.HTTPAddr = defaultHTTPAddr
+	}
+
+	if cfg.HTTPReadHeaderTimeout <= 0 {
+		cfg.HTTPReadHeaderTimeout = defaultHTTPReadHeaderTimeout
+	}
+
+	if cfg.HTTPShutdownTimeout <= 0 {
+		cfg.HTTPShutdownTimeout = defaultHTTPShutdownTimeout
+	}
+
+	if cfg.ListMaxPageSize <= 0 {
+		cfg.ListMaxPageSize = defaultListMaxPageSize
+	}
+
+	if cfg.ListPageSize <= 0 {
+		cfg.ListPageSize = min(defaultListPageSize, cfg.ListMaxPageSize)
+	}
+
+	if cfg.DefaultLocale == "" {
+		cfg.DefaultLocale = defaultLocale
+	}
+
+	if cfg.TenantDeletionGracePeriod <= 0 {
+		cfg.TenantDeletionGracePeriod = defaultTenantDeletionGracePeriod
+	}
+
+	if cfg.TenantPurgeInterval <= 0 {
+		cfg.TenantPurgeInterval = defaultTenantPurgeInterval
+	}
 }
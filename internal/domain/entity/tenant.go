@@ -6,15 +6,34 @@ import (
 	"github.com/google/uuid"
 )
 
+const (
+	// TenantPublicIDPrefix starts the public ids of tenants, see util.NewPublicID.
+	TenantPublicIDPrefix = "tn_"
+	// TenantProductionTypeEnterprise is the production type of the tenants created through the API, whose
+	// requests cannot choose another one.
+	TenantProductionTypeEnterprise = 1
+)
+
+// Tenant is an organisation using the service. Its StatusReason explains the last change of its status, such as
+// why it was suspended. A deleted tenant keeps its row, marked by DeletedAt, until it is purged, and the status it
+// had before, which it gets back when it is undeleted. Its PublicID identifies it to people, in URLs and support
+// tickets, instead of its ID.
 type Tenant struct {
-	ID                      uuid.UUID `db:"id"                        json:"id"`
-	Name                    string    `db:"name"                      json:"name"`
-	Status                  int       `db:"status"                    json:"status"`
-	Domain                  string    `db:"port"                      json:"port"`
-	Timezone                string    `db:"timezone"                  json:"timezone"`
-	ProductionType          int       `db:"production_type"           json:"production_type"`
-	SubscriptionType        int       `db:"subscription_type"         json:"subscription_type"`
-	SelfRegistrationEnabled bool      `db:"self_registration_enabled" json:"self_registration_enabled"`
-	CreatedAt               time.Time `db:"created_at"                json:"created_at"`
-	UpdatedAt               time.Time `db:"updated_at"                json:"updated_at"`
+	ID                      uuid.UUID    `db:"id,pk"                     json:"id"`
+	Name                    string       `db:"name"                      json:"name"`
+	Status                  TenantStatus `db:"status"                    json:"status"`
+	Domain                  string       `db:"port"                      json:"domain"`
+	Timezone                string       `db:"timezone"                  json:"timezone"`
+	ProductionType          int          `db:"production_type"           json:"production_type"`
+	SubscriptionType        int          `db:"subscription_type"         json:"subscription_type"`
+	SelfRegistrationEnabled bool         `db:"self_registration_enabled" json:"self_registration_enabled"`
+	CreatedAt               time.Time    `db:"created_at,immutable"      json:"created_at"`
+	UpdatedAt               time.Time    `db:"updated_at"                json:"updated_at"`
+	Version                 int          `db:"version,version,default=1" json:"version"`
+	StatusReason            string       `db:"status_reason"             json:"status_reason,omitempty"`
+	StatusChangedAt         *time.Time   `db:"status_changed_at"         json:"status_changed_at,omitempty"`
+	DeletedAt               *time.Time   `db:"deleted_at,softdelete"     json:"deleted_at,omitempty"`
+	DeletedBy               string       `db:"deleted_by"                json:"deleted_by,omitempty"`
+	StatusBeforeDeletion    TenantStatus `db:"status_before_deletion"    json:"-"`
+	PublicID                string       `db:"public_id,immutable"       json:"public_id"`
 }
@@ -0,0 +1,73 @@
+package entity
+
+import (
+	"slices"
+)
+
+// TenantStatus is the stage of the lifecycle of a tenant. Its values are stored in the status column, and the
+// first three match the TenantStatus enum of the API.
+type TenantStatus int
+
+const (
+	// TenantStatusPending is the status of a tenant that has been created but is not ready for use yet.
+	TenantStatusPending TenantStatus = iota + 1
+	TenantStatusActive
+	// TenantStatusDeactivated is the status of a tenant that its admin has closed.
+	TenantStatusDeactivated
+	// TenantStatusSuspended is the status of a tenant that the operators have locked, for example for an unpaid
+	// subscription.
+	TenantStatusSuspended
+	// TenantStatusPendingDeletion is the status of a tenant that has been deleted but can still be restored. Once
+	// its grace period is over, the tenant is purged along with its row, so that it has no status after it.
+	TenantStatusPendingDeletion
+)
+
+var tenantStatusNames = map[TenantStatus]string{
+	TenantStatusPending:         "pending",
+	TenantStatusActive:          "active",
+	TenantStatusDeactivated:     "deactivated",
+	TenantStatusSuspended:       "suspended",
+	TenantStatusPendingDeletion: "pending_deletion",
+}
+
+// blockedTenantStatuses are the statuses in which the users of a tenant cannot access it.
+var blockedTenantStatuses = []TenantStatus{
+	TenantStatusSuspended,
+	TenantStatusDeactivated,
+	TenantStatusPendingDeletion,
+}
+
+// tenantTransitions lists the statuses that a tenant can move to from each status. A tenant pending deletion goes
+// back to the status it had before when it is undeleted.
+var tenantTransitions = map[TenantStatus][]TenantStatus{
+	TenantStatusPending:     {TenantStatusActive, TenantStatusDeactivated, TenantStatusPendingDeletion},
+	TenantStatusActive:      {TenantStatusSuspended, TenantStatusDeactivated, TenantStatusPendingDeletion},
+	TenantStatusSuspended:   {TenantStatusActive, TenantStatusDeactivated, TenantStatusPendingDeletion},
+	TenantStatusDeactivated: {TenantStatusActive, TenantStatusPendingDeletion},
+	TenantStatusPendingDeletion: {
+		TenantStatusPending,
+		TenantStatusActive,
+		TenantStatusSuspended,
+		TenantStatusDeactivated,
+	},
+}
+
+func (s TenantStatus) String() string {
+	if name, ok := tenantStatusNames[s]; ok {
+		return name
+	}
+
+	return "unknown"
+}
+
+// CanTransitionTo reports whether a tenant in this status can move to the next one.
+func (s TenantStatus) CanTransitionTo(next TenantStatus) bool {
+	return slices.Contains(tenantTransitions[s], next)
+}
+
+// Usable reports whether the users of the tenant can access it, which other services check before serving them.
+// Pending tenants are usable, so that they can be set up, while the tenants that have been suspended, deactivated
+// or deleted are not.
+func (s TenantStatus) Usable() bool {
+	return !slices.Contains(blockedTenantStatuses, s)
+}
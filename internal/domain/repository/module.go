@@ -7,6 +7,6 @@ import (
 
 func Register() fx.Option {
 	return fx.Provide(
-		ioc.RegisterWithName(NewTenantRepo, "tenant_repo"),
+		ioc.RegisterWithName(NewTenantRepo, "tenant_store"),
 	)
 }
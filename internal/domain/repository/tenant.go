@@ -3,14 +3,13 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"time"
 
 	"github.com/vnworkday/account/internal/common/domain"
 
 	"github.com/vnworkday/account/internal/common/repo"
 	"github.com/vnworkday/account/internal/domain/entity"
 
-	"github.com/pkg/errors"
-
 	"go.uber.org/fx"
 
 	"github.com/google/uuid"
@@ -21,6 +20,9 @@ type TenantRepo interface {
 	FindByPublicID(ctx context.Context, publicID string) (*entity.Tenant, error)
 	FindAll(ctx context.Context, request *domain.ListRequest) ([]*entity.Tenant, error)
 
+	// ExistByName, ExistByDomain and ExistByNameAndIDNot see the deleted tenants as well, which keep their name
+	// and domain until they are purged, since the unique indexes of these columns, tenant_name_key and
+	// tenant_port_key, cover them too.
 	ExistByName(ctx context.Context, name string) (bool, error)
 	ExistByDomain(ctx context.Context, domain string) (bool, error)
 	ExistByNameAndIDNot(ctx context.Context, name string, id uuid.UUID) (bool, error)
@@ -28,6 +30,16 @@ type TenantRepo interface {
 	CountAll(ctx context.Context, request *domain.ListRequest) (int64, error)
 
 	Save(ctx context.Context, tenant *entity.Tenant) error
+	// Update writes the given columns of the tenant, or all of them, if it is still at its version, and fails
+	// with a *repo.ConflictError otherwise.
+	Update(ctx context.Context, tenant *entity.Tenant, columns ...string) error
+
+	// Unscoped returns a repository that sees the deleted tenants as well, which the others hide.
+	Unscoped() TenantRepo
+	// FindAllDeletedBefore returns up to limit tenants deleted before the given time, the oldest first.
+	FindAllDeletedBefore(ctx context.Context, before time.Time, limit int) ([]*entity.Tenant, error)
+	// Purge removes the row of the tenant for good, whether it is deleted or not.
+	Purge(ctx context.Context, id uuid.UUID) error
 }
 
 type TenantRepoParams struct {
@@ -36,158 +48,57 @@ type TenantRepoParams struct {
 }
 
 func NewTenantRepo(params TenantRepoParams) (TenantRepo, error) {
-	table, err := domain.StructToTable(entity.Tenant{}, "tenant")
+	base, err := repo.NewRepository[entity.Tenant](params.DB, "tenant")
 	if err != nil {
 		return nil, err
 	}
 
-	return &tenantRepo{
-		db:    params.DB,
-		table: table,
-	}, nil
+	return &tenantRepo{Repository: base}, nil
 }
 
 type tenantRepo struct {
-	db    *sql.DB
-	table *domain.Table
+	*repo.Repository[entity.Tenant]
 }
 
 func (r tenantRepo) ExistByNameAndIDNot(ctx context.Context, name string, id uuid.UUID) (bool, error) {
-	return repo.NewQueryBuilder[entity.Tenant]().
-		SelectExists().
-		From(r.table.Name).
-		Where(domain.Filter{
-			Field: "name",
-			Op:    domain.Eq,
-			Value: name,
-		}).
-		Where(domain.Filter{
-			Field: "id",
-			Op:    domain.Ne,
-			Value: id,
-		}).
-		Exist(ctx, r.db)
+	return r.Repository.Unscoped().Exists(ctx,
+		domain.Filter{Field: "name", Op: domain.Eq, Value: name},
+		domain.Filter{Field: "id", Op: domain.Ne, Value: id},
+	)
 }
 
 func (r tenantRepo) ExistByDomain(ctx context.Context, domainStr string) (bool, error) {
-	return repo.NewQueryBuilder[entity.Tenant]().
-		SelectExists().
-		From(r.table.Name).
-		Where(domain.Filter{
-			Field: "port",
-			Op:    domain.Eq,
-			Value: domainStr,
-		}).
-		Exist(ctx, r.db)
+	return r.Repository.Unscoped().Exists(ctx, domain.Filter{Field: "port", Op: domain.Eq, Value: domainStr})
 }
 
 func (r tenantRepo) ExistByName(ctx context.Context, name string) (bool, error) {
-	return repo.NewQueryBuilder[entity.Tenant]().
-		SelectExists().
-		From(r.table.Name).
-		Where(domain.Filter{
-			Field: "name",
-			Op:    domain.Eq,
-			Value: name,
-		}).
-		Exist(ctx, r.db)
+	return r.Repository.Unscoped().Exists(ctx, domain.Filter{Field: "name", Op: domain.Eq, Value: name})
 }
 
 func (r tenantRepo) CountAll(ctx context.Context, request *domain.ListRequest) (int64, error) {
-	countBuilder := repo.NewQueryBuilder[entity.Tenant]().
-		SelectCount().
-		From(r.table.Name)
-
-	for _, filter := range request.Filters {
-		countBuilder = countBuilder.Where(filter)
-	}
-
-	count, err := countBuilder.Count(ctx, r.db)
-	if err != nil {
-		return 0, errors.Wrap(err, "repository: failed to count tenants")
-	}
-
-	return count, nil
-}
-
-func (r tenantRepo) FindAll(ctx context.Context, request *domain.ListRequest) ([]*entity.Tenant, error) {
-	queryBuilder := repo.NewQueryBuilder[entity.Tenant]().
-		Select(r.table.Columns...).
-		From(r.table.Name)
-
-	for _, filter := range request.Filters {
-		queryBuilder = queryBuilder.Where(filter)
-	}
-
-	for _, sort := range request.Sorts {
-		queryBuilder = queryBuilder.OrderBy(sort)
-	}
-
-	tenants, err := queryBuilder.QueryAll(ctx, r.db, r.scanTo)
-	if err != nil {
-		return nil, errors.Wrap(err, "repository: failed to find tenants")
-	}
-
-	return tenants, nil
+	return r.Count(ctx, request.Filters...)
 }
 
 func (r tenantRepo) FindByID(ctx context.Context, id uuid.UUID) (*entity.Tenant, error) {
-	return repo.NewQueryBuilder[entity.Tenant]().
-		Select(r.table.Columns...).
-		From(r.table.Name).
-		Where(domain.Filter{
-			Field: "id",
-			Op:    domain.Eq,
-			Value: id,
-		}).
-		Query(ctx, r.db, r.scanTo)
+	return r.Repository.FindByID(ctx, id)
 }
 
-func (r tenantRepo) FindByPublicID(ctx context.Context, publicID string) (*entity.Tenant, error) {
-	return repo.NewQueryBuilder[entity.Tenant]().
-		Select(r.table.Columns...).
-		From(r.table.Name).
-		Where(domain.Filter{
-			Field: "public_id",
-			Op:    domain.Eq,
-			Value: publicID,
-		}).
-		Query(ctx, r.db, r.scanTo)
+func (r tenantRepo) Unscoped() TenantRepo {
+	return &tenantRepo{Repository: r.Repository.Unscoped()}
 }
 
-func (r tenantRepo) Save(ctx context.Context, tenant *entity.Tenant) error {
-	_, err := repo.NewMutationBuilder[entity.Tenant]().
-		MergeInto(r.table.Name).
-		Using(tenant).
-		On(repo.MergeCondition{
-			SourceCol: "id",
-			TargetCol: "id",
-			Op:        domain.Eq,
-		}).
-		WhenMatched().
-		ThenUpdate(r.table.Updatable...).
-		WhenNotMatched().
-		ThenInsert(r.table.Insertable...).
-		Exec(ctx, r.db)
-
-	return err
+func (r tenantRepo) FindAllDeletedBefore(ctx context.Context, before time.Time, limit int) ([]*entity.Tenant, error) {
+	return r.Repository.Unscoped().FindAll(ctx, &domain.ListRequest{
+		Pagination: domain.Pagination{Limit: limit},
+		Filters:    []domain.Condition{domain.Filter{Field: "deleted_at", Op: domain.Lt, Value: before}},
+		Sorts:      []domain.Sort{{Field: "deleted_at", Order: domain.Asc}},
+	})
 }
 
-func (r tenantRepo) scanTo(rows *sql.Rows, tenant entity.Tenant) error {
-	if err := rows.Scan(
-		&tenant.ID,
-		&tenant.Name,
-		&tenant.Status,
-		&tenant.Domain,
-		&tenant.Timezone,
-		&tenant.ProductionType,
-		&tenant.SubscriptionType,
-		&tenant.SelfRegistrationEnabled,
-		&tenant.CreatedAt,
-		&tenant.UpdatedAt,
-	); err != nil {
-		return err
-	}
+func (r tenantRepo) Purge(ctx context.Context, id uuid.UUID) error {
+	return r.Delete(ctx, id)
+}
 
-	return nil
+func (r tenantRepo) FindByPublicID(ctx context.Context, publicID string) (*entity.Tenant, error) {
+	return r.FindOne(ctx, domain.Filter{Field: "public_id", Op: domain.Eq, Value: publicID})
 }
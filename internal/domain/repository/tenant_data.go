@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/repo"
+	"github.com/vnworkday/account/internal/conf"
+	"go.uber.org/fx"
+)
+
+// TenantDataPurger deletes the rows that other tables keep for a tenant, such as its accounts, when the tenant is
+// purged. The tables are those of the TenantDataTables setting, which are purged in the given order so that rows
+// go before the rows they reference.
+type TenantDataPurger struct {
+	db     repo.Executor
+	tables []tenantDataTable
+}
+
+type tenantDataTable struct {
+	name   string
+	column string
+}
+
+type TenantDataPurgerParams struct {
+	fx.In
+	DB     *sql.DB
+	Config *conf.Conf
+}
+
+func NewTenantDataPurger(params TenantDataPurgerParams) (*TenantDataPurger, error) {
+	return newTenantDataPurger(params.DB, params.Config.TenantDataTables)
+}
+
+// newTenantDataPurger reads the tables from entries such as "account.tenant_id", which name a table and its column
+// that holds the id of the tenant.
+func newTenantDataPurger(db repo.Executor, entries []string) (*TenantDataPurger, error) {
+	tables := make([]tenantDataTable, 0, len(entries))
+
+	for _, entry := range entries {
+		name, column, ok := strings.Cut(entry, ".")
+		if !ok || name == "" || column == "" {
+			return nil, errors.Errorf("repository: tenant data table %q must be a table and a column, such as "+
+				"account.tenant_id", entry)
+		}
+
+		tables = append(tables, tenantDataTable{name: name, column: column})
+	}
+
+	return &TenantDataPurger{db: db, tables: tables}, nil
+}
+
+func (p *TenantDataPurger) PurgeTenantData(ctx context.Context, tenantID uuid.UUID) error {
+	for _, table := range p.tables {
+		_, err := repo.NewDeleteBuilder[struct{}]().
+			Placeholder(repo.Dollar).
+			DeleteFrom(table.name).
+			Where(domain.Filter{Field: table.column, Op: domain.Eq, Value: tenantID}).
+			Exec(ctx, repo.Conn(ctx, p.db))
+		if err != nil {
+			return errors.Wrapf(err, "repository: failed to purge %s of tenant %s", table.name, tenantID)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/vnworkday/account/internal/common/fixture"
+	"github.com/vnworkday/account/internal/common/repo"
+)
+
+// recordingExecutor logs the statements that it executes. Queries are not supported and panic.
+type recordingExecutor struct {
+	repo.Executor
+
+	statements []string
+}
+
+func (e *recordingExecutor) ExecContext(_ context.Context, query string, _ ...any) (sql.Result, error) {
+	e.statements = append(e.statements, query)
+
+	return driver.RowsAffected(1), nil
+}
+
+func TestTenantDataPurger_PurgeTenantData(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		entries []string
+		want    []string
+		wantErr bool
+	}{
+		{name: "WithoutTables", entries: nil, want: nil},
+		{
+			name:    "WithTables",
+			entries: []string{"membership.tenant_id", "account.tenant_id"},
+			want: []string{
+				"DELETE FROM membership WHERE tenant_id = $1",
+				"DELETE FROM account WHERE tenant_id = $1",
+			},
+		},
+		{name: "WithoutColumn", entries: []string{"account"}, wantErr: true},
+		{name: "WithEmptyTable", entries: []string{".tenant_id"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			executor := new(recordingExecutor)
+
+			purger, err := newTenantDataPurger(executor, tt.entries)
+			if err == nil {
+				err = purger.PurgeTenantData(context.Background(), uuid.New())
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, executor.statements, tt.wantErr, err)
+		})
+	}
+}
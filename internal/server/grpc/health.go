@@ -0,0 +1,117 @@
+package grpc
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/vnworkday/account/internal/conf"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// HealthServer implements the grpc.health.v1 protocol, deriving the serving status from the database connectivity.
+type HealthServer struct {
+	server   *health.Server
+	db       *sql.DB
+	logger   *zap.Logger
+	interval time.Duration
+	services []string
+	cancel   context.CancelFunc
+	done     sync.WaitGroup
+}
+
+type HealthServerParams struct {
+	fx.In
+	Logger *zap.Logger
+	Config *conf.Conf
+	DB     *sql.DB
+}
+
+func NewHealthServer(params HealthServerParams) *HealthServer {
+	server := health.NewServer()
+	server.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	return &HealthServer{
+		server:   server,
+		db:       params.DB,
+		logger:   params.Logger.With(zap.String("server", "grpc_health")),
+		interval: params.Config.GRPCHealthCheckInterval,
+	}
+}
+
+func (h *HealthServer) Register(registrar googlegrpc.ServiceRegistrar) {
+	healthpb.RegisterHealthServer(registrar, h.server)
+}
+
+// Watch makes the status of the given services follow the overall status of the server.
+func (h *HealthServer) Watch(services ...string) {
+	h.services = append(h.services, services...)
+}
+
+// Start performs a first check synchronously, then keeps probing the dependencies in the background.
+func (h *HealthServer) Start(ctx context.Context) error {
+	h.check(ctx)
+
+	loopCtx, cancel := context.WithCancel(context.Background())
+	h.cancel = cancel
+
+	h.done.Add(1)
+
+	go func() {
+		defer h.done.Done()
+
+		ticker := time.NewTicker(h.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-loopCtx.Done():
+				return
+			case <-ticker.C:
+				h.check(loopCtx)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop ends the background probing.
+func (h *HealthServer) Stop(_ context.Context) error {
+	if h.cancel != nil {
+		h.cancel()
+	}
+
+	h.done.Wait()
+
+	return nil
+}
+
+// Shutdown marks every service as NOT_SERVING and ignores any later status update.
+func (h *HealthServer) Shutdown() {
+	h.server.Shutdown()
+}
+
+func (h *HealthServer) check(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, h.interval)
+	defer cancel()
+
+	status := healthpb.HealthCheckResponse_SERVING
+
+	if err := h.db.PingContext(ctx); err != nil {
+		h.logger.Warn("database is unreachable", zap.Error(err))
+
+		status = healthpb.HealthCheckResponse_NOT_SERVING
+	}
+
+	h.server.SetServingStatus("", status)
+
+	for _, service := range h.services {
+		h.server.SetServingStatus(service, status)
+	}
+}
@@ -0,0 +1,25 @@
+package grpc
+
+import (
+	"context"
+	"strings"
+
+	"github.com/vnworkday/account/internal/common/i18n"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// localeInterceptor puts in the context of every call the locale that its accept-language metadata prefers, or
+// the fallback.
+func localeInterceptor(fallback i18n.Locale) googlegrpc.UnaryServerInterceptor {
+	return func(
+		ctx context.Context,
+		request any,
+		_ *googlegrpc.UnaryServerInfo,
+		handler googlegrpc.UnaryHandler,
+	) (any, error) {
+		acceptLanguage := strings.Join(metadata.ValueFromIncomingContext(ctx, i18n.AcceptLanguageKey), ",")
+
+		return handler(i18n.WithLocale(ctx, i18n.Negotiate(acceptLanguage, fallback)), request)
+	}
+}
@@ -1,9 +1,35 @@
 package grpc
 
-import "go.uber.org/fx"
+import (
+	"context"
+
+	"github.com/vnworkday/common/pkg/ioc"
+	"go.uber.org/fx"
+)
 
 func Register() fx.Option {
-	return fx.Provide(
-		NewTenantGRPCServer,
+	return fx.Options(
+		fx.Provide(
+			ioc.RegisterWithGroup(NewTenantGRPCServer, "grpc_services", new(Service)),
+			fx.Annotate(
+				NewHealthServer,
+				fx.OnStart(func(ctx context.Context, health *HealthServer) error {
+					return health.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, health *HealthServer) error {
+					return health.Stop(ctx)
+				}),
+			),
+			fx.Annotate(
+				NewServer,
+				fx.OnStart(func(ctx context.Context, server *Server) error {
+					return server.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, server *Server) error {
+					return server.Stop(ctx)
+				}),
+			),
+		),
+		fx.Invoke(func(*Server) {}),
 	)
 }
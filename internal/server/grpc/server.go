@@ -0,0 +1,118 @@
+package grpc
+
+import (
+	"context"
+	"net"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/i18n"
+	"github.com/vnworkday/account/internal/conf"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+)
+
+// Service is implemented by every gRPC service provider that should be served by the Server.
+type Service interface {
+	Register(registrar googlegrpc.ServiceRegistrar)
+}
+
+type Server struct {
+	server *googlegrpc.Server
+	health *HealthServer
+	logger *zap.Logger
+	config *conf.Conf
+}
+
+type ServerParams struct {
+	fx.In
+	Logger   *zap.Logger
+	Config   *conf.Conf
+	Health   *HealthServer
+	Services []Service `group:"grpc_services"`
+}
+
+func NewServer(params ServerParams) *Server {
+	cfg := params.Config
+
+	server := googlegrpc.NewServer(
+		googlegrpc.MaxRecvMsgSize(cfg.GRPCMaxRecvMsgSize),
+		googlegrpc.MaxSendMsgSize(cfg.GRPCMaxSendMsgSize),
+		googlegrpc.KeepaliveParams(keepalive.ServerParameters{
+			MaxConnectionIdle: cfg.GRPCMaxConnectionIdle,
+			Time:              cfg.GRPCKeepaliveTime,
+			Timeout:           cfg.GRPCKeepaliveTimeout,
+		}),
+		googlegrpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             cfg.GRPCKeepaliveMinTime,
+			PermitWithoutStream: true,
+		}),
+		googlegrpc.ChainUnaryInterceptor(localeInterceptor(i18n.Negotiate(cfg.DefaultLocale, i18n.English))),
+	)
+
+	for _, service := range params.Services {
+		service.Register(server)
+	}
+
+	for name := range server.GetServiceInfo() {
+		params.Health.Watch(name)
+	}
+
+	params.Health.Register(server)
+	reflection.Register(server)
+
+	return &Server{
+		server: server,
+		health: params.Health,
+		logger: params.Logger.With(zap.String("server", "grpc")),
+		config: cfg,
+	}
+}
+
+// Start binds the configured address and serves requests in the background.
+func (s *Server) Start(ctx context.Context) error {
+	var lc net.ListenConfig
+
+	listener, err := lc.Listen(ctx, "tcp", s.config.GRPCAddr)
+	if err != nil {
+		return errors.Wrapf(err, "server: cannot listen on %s", s.config.GRPCAddr)
+	}
+
+	s.logger.Info("listening", zap.String("addr", listener.Addr().String()))
+
+	go func() {
+		if serveErr := s.server.Serve(listener); serveErr != nil {
+			s.logger.Error("serve failed", zap.Error(serveErr))
+		}
+	}()
+
+	return nil
+}
+
+// Stop reports NOT_SERVING to health checkers, then drains in-flight requests and forces the shutdown once
+// the drain timeout or the context expires.
+func (s *Server) Stop(ctx context.Context) error {
+	s.health.Shutdown()
+
+	ctx, cancel := context.WithTimeout(ctx, s.config.GRPCShutdownTimeout)
+	defer cancel()
+
+	stopped := make(chan struct{})
+
+	go func() {
+		s.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		s.logger.Info("stopped gracefully")
+	case <-ctx.Done():
+		s.logger.Warn("graceful stop timed out, forcing shutdown")
+		s.server.Stop()
+	}
+
+	return nil
+}
@@ -9,8 +9,13 @@ import (
 	"github.com/vnworkday/account/internal/common/adapter"
 	"github.com/vnworkday/account/internal/usecase/tenant"
 	"go.uber.org/fx"
+	"go.uber.org/zap"
+	googlegrpc "google.golang.org/grpc"
 )
 
+// TenantGRPCServer serves the methods of tenantv1grpc.TenantServiceServer. That service has no method to suspend,
+// reactivate, deactivate, delete or undelete a tenant, so that these status changes are only served over HTTP
+// until the API adds them, see TenantHTTPServer.
 type TenantGRPCServer struct {
 	listTenantHandler   grpc.Handler
 	getTenantHandler    grpc.Handler
@@ -20,34 +25,47 @@ type TenantGRPCServer struct {
 
 type TenantGRPCServerParams struct {
 	fx.In
-	Port tenant.Port
+	Logger *zap.Logger
+	Port   tenant.Port `name:"tenant_port"`
 }
 
-func NewTenantGRPCServer(params TenantGRPCServerParams) tenantv1grpc.TenantServiceServer {
+func NewTenantGRPCServer(params TenantGRPCServerParams) *TenantGRPCServer {
+	logErrors := grpc.ServerErrorHandler(adapter.LogInternalErrors(params.Logger.With(
+		zap.String("server", "grpc"),
+	)))
+
 	return &TenantGRPCServer{
 		listTenantHandler: adapter.NewGRPCServer(
 			params.Port.DoListTenants,
 			tenant.ToListRequest,
 			tenant.ToListResponse,
+			logErrors,
 		),
 		getTenantHandler: adapter.NewGRPCServer(
 			params.Port.DoGetTenant,
 			tenant.ToGetRequest,
 			tenant.ToGetResponse,
+			logErrors,
 		),
 		createTenantHandler: adapter.NewGRPCServer(
 			params.Port.DoCreateTenant,
 			tenant.ToCreateRequest,
 			tenant.ToCreateResponse,
+			logErrors,
 		),
 		updateTenantHandler: adapter.NewGRPCServer(
 			params.Port.DoUpdateTenant,
 			tenant.ToUpdateRequest,
 			tenant.ToUpdateResponse,
+			logErrors,
 		),
 	}
 }
 
+func (s *TenantGRPCServer) Register(registrar googlegrpc.ServiceRegistrar) {
+	tenantv1grpc.RegisterTenantServiceServer(registrar, s)
+}
+
 func (s *TenantGRPCServer) CreateTenant(
 	ctx context.Context,
 	request *tenantv1.CreateTenantRequest,
@@ -0,0 +1,17 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/vnworkday/account/internal/common/i18n"
+)
+
+// localeHandler puts in the context of every request the locale that its Accept-Language header prefers, or the
+// fallback.
+func localeHandler(next http.Handler, fallback i18n.Locale) http.Handler {
+	return http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		locale := i18n.Negotiate(request.Header.Get(i18n.AcceptLanguageKey), fallback)
+
+		next.ServeHTTP(writer, request.WithContext(i18n.WithLocale(request.Context(), locale)))
+	})
+}
@@ -0,0 +1,26 @@
+package http
+
+import (
+	"context"
+
+	"github.com/vnworkday/common/pkg/ioc"
+	"go.uber.org/fx"
+)
+
+func Register() fx.Option {
+	return fx.Options(
+		fx.Provide(
+			ioc.RegisterWithGroup(NewTenantHTTPServer, "http_routes", new(Routes)),
+			fx.Annotate(
+				NewServer,
+				fx.OnStart(func(ctx context.Context, server *Server) error {
+					return server.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, server *Server) error {
+					return server.Stop(ctx)
+				}),
+			),
+		),
+		fx.Invoke(func(*Server) {}),
+	)
+}
@@ -0,0 +1,85 @@
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/i18n"
+	"github.com/vnworkday/account/internal/conf"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// Routes is implemented by every HTTP handler provider that should be mounted on the Server.
+type Routes interface {
+	Register(mux *http.ServeMux)
+}
+
+type Server struct {
+	server *http.Server
+	logger *zap.Logger
+	config *conf.Conf
+}
+
+type ServerParams struct {
+	fx.In
+	Logger *zap.Logger
+	Config *conf.Conf
+	Routes []Routes `group:"http_routes"`
+}
+
+func NewServer(params ServerParams) *Server {
+	mux := http.NewServeMux()
+
+	for _, routes := range params.Routes {
+		routes.Register(mux)
+	}
+
+	return &Server{
+		server: &http.Server{
+			Addr:              params.Config.HTTPAddr,
+			Handler:           localeHandler(mux, i18n.Negotiate(params.Config.DefaultLocale, i18n.English)),
+			ReadHeaderTimeout: params.Config.HTTPReadHeaderTimeout,
+		},
+		logger: params.Logger.With(zap.String("server", "http")),
+		config: params.Config,
+	}
+}
+
+// Start binds the configured address and serves requests in the background.
+func (s *Server) Start(ctx context.Context) error {
+	var lc net.ListenConfig
+
+	listener, err := lc.Listen(ctx, "tcp", s.config.HTTPAddr)
+	if err != nil {
+		return errors.Wrapf(err, "server: cannot listen on %s", s.config.HTTPAddr)
+	}
+
+	s.logger.Info("listening", zap.String("addr", listener.Addr().String()))
+
+	go func() {
+		if serveErr := s.server.Serve(listener); serveErr != nil && !errors.Is(serveErr, http.ErrServerClosed) {
+			s.logger.Error("serve failed", zap.Error(serveErr))
+		}
+	}()
+
+	return nil
+}
+
+// Stop drains in-flight requests within the shutdown timeout.
+func (s *Server) Stop(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, s.config.HTTPShutdownTimeout)
+	defer cancel()
+
+	if err := s.server.Shutdown(ctx); err != nil {
+		s.logger.Warn("graceful stop timed out, forcing shutdown", zap.Error(err))
+
+		return s.server.Close()
+	}
+
+	s.logger.Info("stopped gracefully")
+
+	return nil
+}
@@ -0,0 +1,392 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+
+	"github.com/go-kit/kit/endpoint"
+	httptransport "github.com/go-kit/kit/transport/http"
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	"github.com/vnworkday/account/internal/common/adapter"
+	"github.com/vnworkday/account/internal/common/converter"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/parser"
+	"github.com/vnworkday/account/internal/common/repo"
+	"github.com/vnworkday/account/internal/common/util"
+	"github.com/vnworkday/account/internal/domain/entity"
+	"github.com/vnworkday/account/internal/usecase/tenant"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+const (
+	queryOffset = "offset"
+	queryLimit  = "limit"
+	queryOrder  = "order_by"
+	queryFilter = "filter"
+	queryToken  = "page_token"
+	queryMask   = "update_mask"
+)
+
+type TenantHTTPServer struct {
+	listTenantHandler   http.Handler
+	getTenantHandler    http.Handler
+	createTenantHandler http.Handler
+	updateTenantHandler http.Handler
+
+	suspendTenantHandler    http.Handler
+	reactivateTenantHandler http.Handler
+	deactivateTenantHandler http.Handler
+	deleteTenantHandler     http.Handler
+	undeleteTenantHandler   http.Handler
+}
+
+type TenantHTTPServerParams struct {
+	fx.In
+	Logger *zap.Logger
+	Port   tenant.Port `name:"tenant_port"`
+}
+
+func NewTenantHTTPServer(params TenantHTTPServerParams) *TenantHTTPServer {
+	server := new(TenantHTTPServer)
+	logErrors := httptransport.ServerErrorHandler(adapter.LogInternalErrors(params.Logger.With(
+		zap.String("server", "http"),
+	)))
+
+	server.listTenantHandler = adapter.NewHTTPServer(
+		params.Port.DoListTenants,
+		decodeListRequest,
+		httptransport.EncodeJSONResponse,
+		logErrors,
+	)
+	server.getTenantHandler = adapter.NewHTTPServer(
+		params.Port.DoGetTenant,
+		decodeGetRequest,
+		encodeTenantResponse,
+		logErrors,
+	)
+	server.createTenantHandler = adapter.NewHTTPServer(
+		params.Port.DoCreateTenant,
+		decodeCreateRequest,
+		encodeCreateResponse,
+		logErrors,
+	)
+	server.updateTenantHandler = adapter.NewHTTPServer(
+		ifMatchFailed(params.Port.DoUpdateTenant),
+		decodeUpdateRequest,
+		encodeTenantResponse,
+		logErrors,
+	)
+	server.suspendTenantHandler = adapter.NewHTTPServer(
+		params.Port.DoSuspendTenant,
+		decodeChangeStatusRequest,
+		encodeTenantResponse,
+		logErrors,
+	)
+	server.reactivateTenantHandler = adapter.NewHTTPServer(
+		params.Port.DoReactivateTenant,
+		decodeChangeStatusRequest,
+		encodeTenantResponse,
+		logErrors,
+	)
+	server.deactivateTenantHandler = adapter.NewHTTPServer(
+		params.Port.DoDeactivateTenant,
+		decodeChangeStatusRequest,
+		encodeTenantResponse,
+		logErrors,
+	)
+	server.deleteTenantHandler = adapter.NewHTTPServer(
+		params.Port.DoDeleteTenant,
+		decodeDeleteRequest,
+		encodeTenantResponse,
+		logErrors,
+	)
+	server.undeleteTenantHandler = adapter.NewHTTPServer(
+		params.Port.DoUndeleteTenant,
+		decodeChangeStatusRequest,
+		encodeTenantResponse,
+		logErrors,
+	)
+
+	return server
+}
+
+func (s *TenantHTTPServer) Register(mux *http.ServeMux) {
+	mux.Handle("GET /v1/tenants", s.listTenantHandler)
+	mux.Handle("GET /v1/tenants/{id}", s.getTenantHandler)
+	mux.Handle("POST /v1/tenants", s.createTenantHandler)
+	mux.Handle("PATCH /v1/tenants/{id}", s.updateTenantHandler)
+	mux.Handle("POST /v1/tenants/{id}/suspend", s.suspendTenantHandler)
+	mux.Handle("POST /v1/tenants/{id}/reactivate", s.reactivateTenantHandler)
+	mux.Handle("POST /v1/tenants/{id}/deactivate", s.deactivateTenantHandler)
+	mux.Handle("DELETE /v1/tenants/{id}", s.deleteTenantHandler)
+	mux.Handle("POST /v1/tenants/{id}/undelete", s.undeleteTenantHandler)
+}
+
+// decodeListRequest maps the offset, limit, page_token, order_by and filter query parameters onto a list
+// request. The filter is an AIP-160 expression over the fields of tenant.FilterSchema and order_by an AIP-132
+// sort list such as "name desc, created_at".
+func decodeListRequest(_ context.Context, request *http.Request) (any, error) {
+	query := request.URL.Query()
+
+	offset, err := parseIntParam(query, queryOffset)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, err := parseIntParam(query, queryLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	sorts, err := tenant.ParseSorts(query.Get(queryOrder))
+	if err != nil {
+		return nil, badRequest(err)
+	}
+
+	filters := make([]domain.Condition, 0, 1)
+
+	filter, err := parser.ParseFilter(query.Get(queryFilter), tenant.FilterSchema)
+	if err != nil {
+		return nil, badRequest(err)
+	}
+
+	if filter != nil {
+		filters = append(filters, filter)
+	}
+
+	return &domain.ListRequest{
+		Pagination: domain.Pagination{
+			Offset: offset,
+			Limit:  limit,
+			Token:  query.Get(queryToken),
+		},
+		Filters: filters,
+		Sorts:   sorts,
+	}, nil
+}
+
+// decodeGetRequest takes the public id of the tenant as well as its UUID. It lets the administration API look up
+// tenants whatever their status, unlike the services that act on behalf of the users of a tenant.
+func decodeGetRequest(_ context.Context, request *http.Request) (any, error) {
+	if publicID := request.PathValue("id"); util.IsPublicID(entity.TenantPublicIDPrefix, publicID) {
+		return &tenant.GetTenantRequest{PublicID: publicID, AllowUnusable: true}, nil
+	}
+
+	id, err := parseIDParam(request)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tenant.GetTenantRequest{ID: id, AllowUnusable: true}, nil
+}
+
+func decodeCreateRequest(_ context.Context, request *http.Request) (any, error) {
+	var req tenant.CreateTenantRequest
+
+	if err := json.NewDecoder(request.Body).Decode(&req); err != nil {
+		return nil, badRequest(errors.Wrap(err, "server: malformed request body"))
+	}
+
+	return &req, nil
+}
+
+// decodeUpdateRequest takes the version that the update is based on from the If-Match header, which is required,
+// so that a missing header is reported with 428 Precondition Required. The fields to change are those of the
+// update_mask query parameter, such as "name,subscription_type", or else those present in the body, so that a
+// PATCH leaves the others untouched.
+func decodeUpdateRequest(_ context.Context, request *http.Request) (any, error) {
+	id, err := parseIDParam(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var req tenant.UpdateTenantRequest
+	var fields map[string]json.RawMessage
+
+	body, err := io.ReadAll(request.Body)
+	if err != nil {
+		return nil, badRequest(errors.Wrap(err, "server: cannot read request body"))
+	}
+
+	if err = json.Unmarshal(body, &req); err != nil {
+		return nil, badRequest(errors.Wrap(err, "server: malformed request body"))
+	}
+
+	if err = json.Unmarshal(body, &fields); err != nil {
+		return nil, badRequest(errors.Wrap(err, "server: malformed request body"))
+	}
+
+	req.ID = id
+
+	if req.Mask, err = parseUpdateMask(request.URL.Query(), fields); err != nil {
+		return nil, badRequest(err)
+	}
+
+	if req.Version, err = converter.ParseETag(request.Header.Get(converter.IfMatchKey)); err != nil {
+		return nil, badRequest(err)
+	}
+
+	if req.Version == 0 {
+		return nil, adapter.NewHTTPError(http.StatusPreconditionRequired,
+			errs.New(errs.FailedPrecondition, "If-Match header is required to update a tenant").
+				WithReason(converter.ReasonVersionRequired).
+				WithResource("tenant", id.String()))
+	}
+
+	return &req, nil
+}
+
+// ifMatchFailed reports the version mismatches of the endpoint with 412 Precondition Failed, since the version
+// that its writes are based on comes from the If-Match header. Other conflicts keep 409 Conflict.
+func ifMatchFailed(next endpoint.Endpoint) endpoint.Endpoint {
+	return func(ctx context.Context, request any) (any, error) {
+		response, err := next(ctx, request)
+		if typed := errs.As(err); typed != nil && typed.ErrorReason() == repo.ReasonVersionMismatch {
+			return nil, adapter.NewHTTPError(http.StatusPreconditionFailed, err)
+		}
+
+		return response, err
+	}
+}
+
+// decodeChangeStatusRequest reads the reason of the status change from a body such as {"reason": "unpaid"}. An
+// empty body is an empty request, which the validation of the request reports field by field.
+func decodeChangeStatusRequest(_ context.Context, request *http.Request) (any, error) {
+	id, err := parseIDParam(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var req tenant.ChangeTenantStatusRequest
+
+	if err = decodeOptionalBody(request, &req); err != nil {
+		return nil, err
+	}
+
+	req.ID = id
+
+	return &req, nil
+}
+
+// decodeDeleteRequest reads who the client says deletes the tenant, and why, from a body such as
+// {"deleted_by": "admin@example.com", "reason": "closed"}. The deleted_by value is stored as it is sent. Like
+// that of decodeChangeStatusRequest, the body may be empty.
+func decodeDeleteRequest(_ context.Context, request *http.Request) (any, error) {
+	id, err := parseIDParam(request)
+	if err != nil {
+		return nil, err
+	}
+
+	var req tenant.DeleteTenantRequest
+
+	if err = decodeOptionalBody(request, &req); err != nil {
+		return nil, err
+	}
+
+	req.ID = id
+
+	return &req, nil
+}
+
+// decodeOptionalBody decodes the JSON body into dest, which it leaves as it is when the body is empty.
+func decodeOptionalBody(request *http.Request, dest any) error {
+	if err := json.NewDecoder(request.Body).Decode(dest); err != nil && !errors.Is(err, io.EOF) {
+		return badRequest(errors.Wrap(err, "server: malformed request body"))
+	}
+
+	return nil
+}
+
+func parseUpdateMask(query url.Values, fields map[string]json.RawMessage) (domain.FieldMask, error) {
+	if query.Has(queryMask) {
+		return parser.ParseFieldMask(query.Get(queryMask), tenant.UpdatableFields)
+	}
+
+	paths := make([]string, 0, len(fields))
+
+	// The id and version of the tenant, which older clients send in the body, are not fields to update.
+	for field := range fields {
+		if field != "id" && field != "version" {
+			paths = append(paths, field)
+		}
+	}
+
+	if len(paths) == 0 {
+		return nil, errors.New("server: request body has no field to update")
+	}
+
+	sort.Strings(paths)
+
+	return parser.NewFieldMask(paths, tenant.UpdatableFields)
+}
+
+// tenantResponse sends the version of the tenant as its ETag, which clients send back in If-Match to update it.
+type tenantResponse struct {
+	*entity.Tenant
+}
+
+func (r tenantResponse) Headers() http.Header {
+	return http.Header{"Etag": []string{converter.FormatETag(r.Version)}}
+}
+
+// createdResponse marks a freshly created resource so that it is written with 201 Created.
+type createdResponse struct {
+	tenantResponse
+}
+
+func (createdResponse) StatusCode() int {
+	return http.StatusCreated
+}
+
+func encodeTenantResponse(ctx context.Context, writer http.ResponseWriter, response any) error {
+	tenant, ok := response.(*entity.Tenant)
+	if !ok {
+		return errors.New("server: cannot cast before encoding")
+	}
+
+	return httptransport.EncodeJSONResponse(ctx, writer, tenantResponse{Tenant: tenant})
+}
+
+func encodeCreateResponse(ctx context.Context, writer http.ResponseWriter, response any) error {
+	created, ok := response.(*entity.Tenant)
+	if !ok {
+		return errors.New("server: cannot cast before encoding")
+	}
+
+	return httptransport.EncodeJSONResponse(ctx, writer, createdResponse{tenantResponse{Tenant: created}})
+}
+
+func parseIDParam(request *http.Request) (uuid.UUID, error) {
+	id, err := uuid.Parse(request.PathValue("id"))
+	if err != nil {
+		return uuid.Nil, badRequest(errors.Wrap(err, "server: invalid tenant id"))
+	}
+
+	return id, nil
+}
+
+func parseIntParam(query url.Values, key string) (int, error) {
+	raw := query.Get(key)
+	if raw == "" {
+		return 0, nil
+	}
+
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		return 0, badRequest(errors.Errorf("server: %s must be a non-negative integer", key))
+	}
+
+	return value, nil
+}
+
+func badRequest(err error) error {
+	return adapter.NewHTTPError(http.StatusBadRequest, err)
+}
@@ -0,0 +1,363 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/vnworkday/account/internal/common/adapter"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/fixture"
+	"github.com/vnworkday/account/internal/common/repo"
+	"github.com/vnworkday/account/internal/domain/entity"
+	"github.com/vnworkday/account/internal/usecase/tenant"
+)
+
+var testTenantID = uuid.MustParse("0190b0b0-7d6c-7b3e-8f4a-2c1d9e8f7a6b")
+
+func TestParseIntParam(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		input   string
+		want    int
+		wantErr bool
+	}{
+		{name: "WithMissingValue", input: "", want: 0},
+		{name: "WithValidValue", input: "5", want: 5},
+		{name: "WithNegativeValue", input: "-1", wantErr: true},
+		{name: "WithNonInteger", input: "five", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := parseIntParam(url.Values{queryLimit: {tt.input}}, queryLimit)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, err)
+		})
+	}
+}
+
+func TestDecodeListRequest(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Pagination domain.Pagination
+		Filters    int
+		Sorts      []domain.Sort
+	}
+
+	tests := []struct {
+		name    string
+		query   string
+		want    result
+		wantErr bool
+	}{
+		{
+			name:  "WithDefaults",
+			query: "",
+			want:  result{Sorts: tenant.DefaultSorts},
+		},
+		{
+			name:  "WithAllParams",
+			query: "offset=10&limit=5&page_token=abc&order_by=name+desc&filter=" + url.QueryEscape(`name = "Acme"`),
+			want: result{
+				Pagination: domain.Pagination{Offset: 10, Limit: 5, Token: "abc"},
+				Filters:    1,
+				Sorts: []domain.Sort{
+					{Field: "name", Order: domain.Desc},
+					{Field: "created_at", Order: domain.Asc},
+					{Field: "id", Order: domain.Asc},
+				},
+			},
+		},
+		{name: "WithNegativeOffset", query: "offset=-1", wantErr: true},
+		{name: "WithInvalidLimit", query: "limit=all", wantErr: true},
+		{name: "WithUnknownSortField", query: "order_by=secret", wantErr: true},
+		{name: "WithUnknownFilterField", query: "filter=" + url.QueryEscape(`secret = "x"`), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			request := httptest.NewRequest(http.MethodGet, "/v1/tenants?"+tt.query, nil)
+
+			var got result
+
+			decoded, err := decodeListRequest(context.Background(), request)
+			if listRequest, ok := decoded.(*domain.ListRequest); ok {
+				got = result{
+					Pagination: listRequest.Pagination,
+					Filters:    len(listRequest.Filters),
+					Sorts:      listRequest.Sorts,
+				}
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, err)
+		})
+	}
+}
+
+func TestDecodeUpdateRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		id      string
+		query   string
+		ifMatch string
+		body    string
+		want    *tenant.UpdateTenantRequest
+		wantErr bool
+	}{
+		{
+			name:    "WithFieldsOfBody",
+			id:      testTenantID.String(),
+			ifMatch: `"3"`,
+			body:    `{"name": "Acme", "subscription_type": 2}`,
+			want: &tenant.UpdateTenantRequest{
+				ID:               testTenantID,
+				Name:             "Acme",
+				SubscriptionType: 2,
+				Version:          3,
+				Mask:             domain.FieldMask{"name", "subscription_type"},
+			},
+		},
+		{
+			name:    "WithVersionInBody",
+			id:      testTenantID.String(),
+			ifMatch: `"7"`,
+			body:    `{"name": "Acme", "version": 3}`,
+			want: &tenant.UpdateTenantRequest{
+				ID:      testTenantID,
+				Name:    "Acme",
+				Version: 7,
+				Mask:    domain.FieldMask{"name"},
+			},
+		},
+		{
+			name:    "WithUpdateMask",
+			id:      testTenantID.String(),
+			query:   "update_mask=self_registration_enabled",
+			ifMatch: "2",
+			body:    `{"name": "Acme", "self_registration_enabled": true}`,
+			want: &tenant.UpdateTenantRequest{
+				ID:                      testTenantID,
+				Name:                    "Acme",
+				SelfRegistrationEnabled: true,
+				Version:                 2,
+				Mask:                    domain.FieldMask{"self_registration_enabled"},
+			},
+		},
+		{
+			name:    "WithNoFieldToUpdate",
+			id:      testTenantID.String(),
+			ifMatch: `"3"`,
+			body:    `{"version": 3}`,
+			wantErr: true,
+		},
+		{
+			name:    "WithUnknownMaskField",
+			id:      testTenantID.String(),
+			query:   "update_mask=domain",
+			ifMatch: `"3"`,
+			body:    `{}`,
+			wantErr: true,
+		},
+		{name: "WithoutIfMatch", id: testTenantID.String(), body: `{"name": "Acme"}`, wantErr: true},
+		{
+			name:    "WithInvalidIfMatch",
+			id:      testTenantID.String(),
+			ifMatch: "v7",
+			body:    `{"name": "Acme"}`,
+			wantErr: true,
+		},
+		{name: "WithMalformedBody", id: testTenantID.String(), body: `{"name":`, wantErr: true},
+		{name: "WithInvalidID", id: "42", body: `{"name": "Acme"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			target := "/v1/tenants/" + tt.id + "?" + tt.query
+			request := httptest.NewRequest(http.MethodPatch, target, strings.NewReader(tt.body))
+			request.SetPathValue("id", tt.id)
+
+			if tt.ifMatch != "" {
+				request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			got, err := decodeUpdateRequest(context.Background(), request)
+
+			fixture.ExpectationsWereMet(t, any(tt.want), got, tt.wantErr, err)
+		})
+	}
+}
+
+func TestDecodeChangeStatusRequest(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		id      string
+		body    string
+		want    *tenant.ChangeTenantStatusRequest
+		wantErr bool
+	}{
+		{
+			name: "WithReason",
+			id:   testTenantID.String(),
+			body: `{"reason": "unpaid"}`,
+			want: &tenant.ChangeTenantStatusRequest{ID: testTenantID, Reason: "unpaid"},
+		},
+		{
+			name: "WithEmptyBody",
+			id:   testTenantID.String(),
+			body: "",
+			want: &tenant.ChangeTenantStatusRequest{ID: testTenantID},
+		},
+		{name: "WithMalformedBody", id: testTenantID.String(), body: `{"reason":`, wantErr: true},
+		{name: "WithInvalidID", id: "42", body: `{"reason": "unpaid"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			target := "/v1/tenants/" + tt.id + "/suspend"
+			request := httptest.NewRequest(http.MethodPost, target, strings.NewReader(tt.body))
+			request.SetPathValue("id", tt.id)
+
+			got, err := decodeChangeStatusRequest(context.Background(), request)
+
+			fixture.ExpectationsWereMet(t, any(tt.want), got, tt.wantErr, err)
+		})
+	}
+}
+
+func TestUpdateTenantStatus(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		ifMatch  string
+		endpoint func(context.Context, any) (any, error)
+		want     int
+	}{
+		{
+			name:    "WithCurrentVersion",
+			ifMatch: `"3"`,
+			endpoint: func(context.Context, any) (any, error) {
+				return &entity.Tenant{ID: testTenantID, Version: 4}, nil
+			},
+			want: http.StatusOK,
+		},
+		{
+			name:    "WithStaleVersion",
+			ifMatch: `"2"`,
+			endpoint: func(context.Context, any) (any, error) {
+				return nil, errs.New(errs.Conflict, "tenant has been modified since version 2").
+					WithReason(repo.ReasonVersionMismatch)
+			},
+			want: http.StatusPreconditionFailed,
+		},
+		{
+			name:    "WithOtherConflict",
+			ifMatch: `"3"`,
+			endpoint: func(context.Context, any) (any, error) {
+				return nil, errs.New(errs.Conflict, "tenant is being deleted")
+			},
+			want: http.StatusConflict,
+		},
+		{
+			name: "WithoutIfMatch",
+			endpoint: func(context.Context, any) (any, error) {
+				return &entity.Tenant{ID: testTenantID, Version: 4}, nil
+			},
+			want: http.StatusPreconditionRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := adapter.NewHTTPServer(ifMatchFailed(tt.endpoint), decodeUpdateRequest, encodeTenantResponse)
+
+			request := httptest.NewRequest(http.MethodPatch, "/v1/tenants/"+testTenantID.String(),
+				strings.NewReader(`{"name": "Acme"}`))
+			request.SetPathValue("id", testTenantID.String())
+
+			if tt.ifMatch != "" {
+				request.Header.Set("If-Match", tt.ifMatch)
+			}
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, request)
+
+			fixture.ExpectationsWereMet(t, tt.want, recorder.Code, false, nil)
+		})
+	}
+}
+
+func TestTenantResponses(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Status int
+		ETag   string
+		ID     string
+	}
+
+	tests := []struct {
+		name   string
+		encode func(ctx context.Context, writer http.ResponseWriter, response any) error
+		want   result
+	}{
+		{
+			name:   "Tenant",
+			encode: encodeTenantResponse,
+			want:   result{Status: http.StatusOK, ETag: `"3"`, ID: testTenantID.String()},
+		},
+		{
+			name:   "Created",
+			encode: encodeCreateResponse,
+			want:   result{Status: http.StatusCreated, ETag: `"3"`, ID: testTenantID.String()},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := adapter.NewHTTPServer(
+				func(context.Context, any) (any, error) {
+					return &entity.Tenant{ID: testTenantID, Version: 3}, nil
+				},
+				decodeCreateRequest,
+				tt.encode,
+			)
+
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/v1/tenants", strings.NewReader(`{}`)))
+
+			var body entity.Tenant
+
+			err := json.Unmarshal(recorder.Body.Bytes(), &body)
+			got := result{Status: recorder.Code, ETag: recorder.Header().Get("ETag"), ID: body.ID.String()}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, false, err)
+		})
+	}
+}
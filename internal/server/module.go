@@ -2,11 +2,13 @@ package server
 
 import (
 	"github.com/vnworkday/account/internal/server/grpc"
+	"github.com/vnworkday/account/internal/server/http"
 	"go.uber.org/fx"
 )
 
 func Register() fx.Option {
 	return fx.Module("server",
 		grpc.Register(),
+		http.Register(),
 	)
 }
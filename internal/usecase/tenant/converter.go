@@ -1,88 +1,228 @@
 package tenant
 
 import (
+	"context"
+
 	tenantv1 "buf.build/gen/go/ntduycs/vnworkday/protocolbuffers/go/account/tenant/v1"
 	sharedv1 "buf.build/gen/go/ntduycs/vnworkday/protocolbuffers/go/shared/v1"
 	"github.com/google/uuid"
 	"github.com/gookit/goutil/arrutil"
+	"github.com/vnworkday/account/internal/common/converter"
 	model2 "github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/parser"
+	"github.com/vnworkday/account/internal/common/util"
 	"github.com/vnworkday/account/internal/domain/entity"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
-func ToCreateRequest(request *tenantv1.CreateTenantRequest) *CreateTenantRequest {
+func parseID(id string) (uuid.UUID, error) {
+	parsed, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, errs.NewInvalidArgument(errs.NewViolation("id", "UUID", nil)).Wrap(err)
+	}
+
+	return parsed, nil
+}
+
+func ToCreateRequest(_ context.Context, request *tenantv1.CreateTenantRequest) (*CreateTenantRequest, error) {
 	return &CreateTenantRequest{
 		Name:                    request.GetName(),
 		Domain:                  request.GetDomain(),
 		Timezone:                request.GetTimezone(),
 		SubscriptionType:        int(request.GetSubscriptionType()),
 		SelfRegistrationEnabled: request.GetSelfRegistrationEnabled(),
-	}
+	}, nil
 }
 
-func ToCreateResponse(response *entity.Tenant) *tenantv1.CreateTenantResponse {
+func ToCreateResponse(ctx context.Context, response *entity.Tenant) (*tenantv1.CreateTenantResponse, error) {
+	converter.SendVersion(ctx, response.Version)
+	converter.SendPublicID(ctx, response.PublicID)
+
 	return &tenantv1.CreateTenantResponse{
 		Tenant: toGrpcTenant(response),
-	}
+	}, nil
 }
 
-func ToUpdateRequest(request *tenantv1.UpdateTenantRequest) *UpdateTenantRequest {
+// ToUpdateRequest reads the version that the update is based on from the if-match metadata of the call, and the
+// fields that it changes from the update-mask metadata, since the message has no field for them. Without if-match
+// metadata, the update applies whatever the version of the tenant.
+func ToUpdateRequest(ctx context.Context, request *tenantv1.UpdateTenantRequest) (*UpdateTenantRequest, error) {
+	id, err := parseID(request.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	version, err := converter.IncomingVersion(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fieldMask, err := converter.IncomingFieldMask(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	mask, err := parser.NewFieldMask(fieldMask.GetPaths(), UpdatableFields)
+	if err != nil {
+		return nil, err
+	}
+
 	return &UpdateTenantRequest{
-		ID:                      uuid.MustParse(request.GetId()),
+		ID:                      id,
 		Name:                    request.GetName(),
 		SubscriptionType:        int(request.GetSubscriptionType()),
 		SelfRegistrationEnabled: request.GetSelfRegistrationEnabled(),
-	}
+		Version:                 version,
+		Mask:                    mask,
+	}, nil
 }
 
-func ToUpdateResponse(response *entity.Tenant) *tenantv1.UpdateTenantResponse {
+func ToUpdateResponse(ctx context.Context, response *entity.Tenant) (*tenantv1.UpdateTenantResponse, error) {
+	converter.SendVersion(ctx, response.Version)
+	converter.SendPublicID(ctx, response.PublicID)
+
 	return &tenantv1.UpdateTenantResponse{
 		Tenant: toGrpcTenant(response),
-	}
+	}, nil
 }
 
-func ToGetRequest(request *tenantv1.GetTenantRequest) *GetTenantRequest {
-	return &GetTenantRequest{
-		ID: uuid.MustParse(request.GetId()),
+// ToGetRequest accepts the public id of the tenant as well as its UUID.
+func ToGetRequest(_ context.Context, request *tenantv1.GetTenantRequest) (*GetTenantRequest, error) {
+	if util.IsPublicID(entity.TenantPublicIDPrefix, request.GetId()) {
+		return &GetTenantRequest{PublicID: request.GetId()}, nil
 	}
+
+	id, err := parseID(request.GetId())
+	if err != nil {
+		return nil, err
+	}
+
+	return &GetTenantRequest{
+		ID: id,
+	}, nil
 }
 
-func ToGetResponse(response *entity.Tenant) *tenantv1.GetTenantResponse {
+func ToGetResponse(ctx context.Context, response *entity.Tenant) (*tenantv1.GetTenantResponse, error) {
+	converter.SendVersion(ctx, response.Version)
+	converter.SendPublicID(ctx, response.PublicID)
+
 	return &tenantv1.GetTenantResponse{
 		Tenant: toGrpcTenant(response),
-	}
+	}, nil
 }
 
-func ToListRequest(_ *tenantv1.ListTenantsRequest) *model2.ListRequest {
+func ToListRequest(_ context.Context, request *tenantv1.ListTenantsRequest) (*model2.ListRequest, error) {
+	filters := make([]model2.Condition, 0, len(request.GetPagination().GetFilters())+len(request.GetFilters()))
+
+	filterLists := [][]*sharedv1.RequestFilter{request.GetPagination().GetFilters(), request.GetFilters()}
+
+	for _, requestFilters := range filterLists {
+		for _, filter := range requestFilters {
+			op, ok := filterOps[filter.GetOperator()]
+			if !ok {
+				return nil, errs.NewInvalidArgument(errs.NewViolation("filters", "FILTER_OPERATOR", map[string]string{
+					"operator": filter.GetOperator().String(),
+				}))
+			}
+
+			// The API separates the values of IN and NOT IN filters with vertical bars.
+			separator := ","
+			if op == model2.In || op == model2.NotIn {
+				separator = "|"
+			}
+
+			condition, err := FilterSchema.FilterList(
+				filter.GetField(), op, filter.GetValue(), separator, filter.GetIsCaseSensitive(),
+			)
+			if err != nil {
+				return nil, err
+			}
+
+			filters = append(filters, condition)
+		}
+	}
+
+	sorts := make([]model2.Sort, 0, len(request.GetPagination().GetSorts())+len(request.GetSorts()))
+
+	for _, requestSorts := range [][]*sharedv1.RequestSort{request.GetPagination().GetSorts(), request.GetSorts()} {
+		for _, sort := range requestSorts {
+			sorts = append(sorts, model2.Sort{Field: sort.GetField(), Order: model2.SortOrder(sort.GetOrder())})
+		}
+	}
+
+	sorts, err := ParseSorts("", sorts...)
+	if err != nil {
+		return nil, err
+	}
+
 	return &model2.ListRequest{
 		Pagination: model2.Pagination{
-			Offset: 0,
-			Limit:  0,
+			Limit: int(request.GetPagination().GetLimit()),
+			Token: request.GetPagination().GetToken(),
 		},
-		Filters: nil,
-		Sorts:   nil,
-	}
+		Filters: filters,
+		Sorts:   sorts,
+	}, nil
 }
 
-func ToListResponse(response *model2.ListResponse[entity.Tenant]) *tenantv1.ListTenantsResponse {
+// ToListResponse sends the public ids of the tenants in the public-id header, in the order of the tenants.
+func ToListResponse(
+	ctx context.Context,
+	response *model2.ListResponse[entity.Tenant],
+) (*tenantv1.ListTenantsResponse, error) {
+	converter.SendPublicID(ctx, arrutil.Map(response.Items, func(input *entity.Tenant) (string, bool) {
+		return input.PublicID, true
+	})...)
+
 	return &tenantv1.ListTenantsResponse{
 		Pagination: &sharedv1.ResponsePagination{
-			NextToken:     "",
-			PreviousToken: "",
+			NextToken:     response.Page.NextToken,
+			PreviousToken: response.Page.PrevToken,
 			Total:         int32(response.Count),
-			TotalPages:    0,
+			TotalPages:    int32(response.Page.TotalPages),
 		},
 		Tenants: arrutil.Map(response.Items, func(input *entity.Tenant) (*tenantv1.Tenant, bool) {
 			return toGrpcTenant(input), true
 		}),
-	}
+	}, nil
+}
+
+var filterOps = map[sharedv1.Operator]model2.Op{
+	sharedv1.Operator_OPERATOR_EQ:           model2.Eq,
+	sharedv1.Operator_OPERATOR_NOT_EQ:       model2.Ne,
+	sharedv1.Operator_OPERATOR_GT:           model2.Gt,
+	sharedv1.Operator_OPERATOR_LT:           model2.Lt,
+	sharedv1.Operator_OPERATOR_GE:           model2.Ge,
+	sharedv1.Operator_OPERATOR_LE:           model2.Le,
+	sharedv1.Operator_OPERATOR_IN:           model2.In,
+	sharedv1.Operator_OPERATOR_NOT_IN:       model2.NotIn,
+	sharedv1.Operator_OPERATOR_CONTAINS:     model2.Contains,
+	sharedv1.Operator_OPERATOR_NOT_CONTAINS: model2.NotContains,
+	sharedv1.Operator_OPERATOR_STARTS_WITH:  model2.StartsWith,
+	sharedv1.Operator_OPERATOR_ENDS_WITH:    model2.EndsWith,
+	sharedv1.Operator_OPERATOR_NULL:         model2.Null,
+	sharedv1.Operator_OPERATOR_NOT_NULL:     model2.NotNull,
+	sharedv1.Operator_OPERATOR_BETWEEN:      model2.Between,
+}
+
+// grpcStatuses maps the statuses of a tenant onto those of the API, which has no counterpart for the statuses
+// that block a tenant and reports them as inactive.
+var grpcStatuses = map[entity.TenantStatus]tenantv1.TenantStatus{
+	entity.TenantStatusPending:         tenantv1.TenantStatus_TENANT_STATUS_PROVISIONING,
+	entity.TenantStatusActive:          tenantv1.TenantStatus_TENANT_STATUS_ACTIVE,
+	entity.TenantStatusDeactivated:     tenantv1.TenantStatus_TENANT_STATUS_INACTIVE,
+	entity.TenantStatusSuspended:       tenantv1.TenantStatus_TENANT_STATUS_INACTIVE,
+	entity.TenantStatusPendingDeletion: tenantv1.TenantStatus_TENANT_STATUS_INACTIVE,
 }
 
+// toGrpcTenant leaves out the public id of the tenant, which tenantv1.Tenant has no field for. The responses send
+// the public ids of their tenants in their public-id header instead, see converter.SendPublicID.
 func toGrpcTenant(from *entity.Tenant) *tenantv1.Tenant {
 	return &tenantv1.Tenant{
 		Id:                      from.ID.String(),
 		Name:                    from.Name,
-		Status:                  tenantv1.TenantStatus(from.Status),
+		Status:                  grpcStatuses[from.Status],
 		Domain:                  from.Domain,
 		Timezone:                from.Timezone,
 		ProductionType:          tenantv1.TenantProductionType(from.ProductionType),
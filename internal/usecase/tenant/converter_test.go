@@ -0,0 +1,66 @@
+package tenant
+
+import (
+	"context"
+	"testing"
+
+	tenantv1 "buf.build/gen/go/ntduycs/vnworkday/protocolbuffers/go/account/tenant/v1"
+	sharedv1 "buf.build/gen/go/ntduycs/vnworkday/protocolbuffers/go/shared/v1"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+func TestToListRequest_Filters(t *testing.T) {
+	t.Parallel()
+
+	nameFilter := &sharedv1.RequestFilter{Field: "name", Operator: sharedv1.Operator_OPERATOR_EQ, Value: "acme"}
+	domainFilter := &sharedv1.RequestFilter{Field: "domain", Operator: sharedv1.Operator_OPERATOR_EQ, Value: "acme.io"}
+
+	tests := []struct {
+		name    string
+		request *tenantv1.ListTenantsRequest
+		want    []domain.Condition
+		wantErr bool
+	}{
+		{
+			name:    "WithFilters",
+			request: &tenantv1.ListTenantsRequest{Filters: []*sharedv1.RequestFilter{nameFilter}},
+			want:    []domain.Condition{domain.Filter{Field: "name", Op: domain.Eq, Value: "acme"}},
+		},
+		{
+			name: "WithPaginationFilters",
+			request: &tenantv1.ListTenantsRequest{
+				Pagination: &sharedv1.RequestPagination{Filters: []*sharedv1.RequestFilter{nameFilter}},
+				Filters:    []*sharedv1.RequestFilter{domainFilter},
+			},
+			want: []domain.Condition{
+				domain.Filter{Field: "name", Op: domain.Eq, Value: "acme"},
+				domain.Filter{Field: "port", Op: domain.Eq, Value: "acme.io"},
+			},
+		},
+		{
+			name: "WithUnknownPaginationFilter",
+			request: &tenantv1.ListTenantsRequest{
+				Pagination: &sharedv1.RequestPagination{Filters: []*sharedv1.RequestFilter{
+					{Field: "secret", Operator: sharedv1.Operator_OPERATOR_EQ, Value: "x"},
+				}},
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []domain.Condition
+
+			request, err := ToListRequest(context.Background(), tt.request)
+			if err == nil {
+				got = request.Filters
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, err)
+		})
+	}
+}
@@ -1,22 +1,100 @@
 package tenant
 
-import "github.com/google/uuid"
+import (
+	"github.com/google/uuid"
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/parser"
+)
 
+// FilterSchema lists the tenant fields that list requests can filter on.
+var FilterSchema = parser.Schema{
+	"id":                        {Column: "id", Type: domain.UUID},
+	"public_id":                 {Column: "public_id", Type: domain.String},
+	"name":                      {Column: "name", Type: domain.String},
+	"status":                    {Column: "status", Type: domain.Integer},
+	"domain":                    {Column: "port", Type: domain.String},
+	"timezone":                  {Column: "timezone", Type: domain.String},
+	"production_type":           {Column: "production_type", Type: domain.Integer},
+	"subscription_type":         {Column: "subscription_type", Type: domain.Integer},
+	"self_registration_enabled": {Column: "self_registration_enabled", Type: domain.Boolean},
+	"created_at":                {Column: "created_at", Type: domain.DateTime},
+	"updated_at":                {Column: "updated_at", Type: domain.DateTime},
+}
+
+// sortFields are the public names of the fields of FilterSchema, which list requests can sort on as well.
+var sortFields = FilterSchema.Fields()
+
+// DefaultSorts order tenant listings whose sorts are missing or tied, ending with the unique id column.
+var DefaultSorts = []domain.Sort{
+	{Field: "created_at", Order: domain.Asc},
+	{Field: "id", Order: domain.Asc},
+}
+
+// ParseSorts validates client sorts against the public names of the tenant fields, maps them onto their columns
+// and completes them with DefaultSorts.
+func ParseSorts(orderBy string, sorts ...domain.Sort) ([]domain.Sort, error) {
+	parsed, err := parser.ParseOrderBy(orderBy, sortFields)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, sort := range sorts {
+		checked, sortErr := parser.NewSort(sort.Field, string(sort.Order), sortFields)
+		if sortErr != nil {
+			return nil, sortErr
+		}
+
+		parsed = append(parsed, checked)
+	}
+
+	return parser.StableSort(FilterSchema.SortColumns(parsed), DefaultSorts...), nil
+}
+
+// UpdatableFields lists the tenant fields that UpdateTenant can change, which are also their columns. The id and
+// the domain of a tenant never change.
+var UpdatableFields = []string{"name", "subscription_type", "self_registration_enabled"}
+
+// GetTenantRequest looks up a tenant by its PublicID when it is set, or else by its ID.
 type GetTenantRequest struct {
-	ID uuid.UUID `json:"id"`
+	ID       uuid.UUID `json:"id"`
+	PublicID string    `json:"public_id"`
+	// AllowUnusable returns the tenants that are not usable as well, see entity.TenantStatus.Usable, which lookups
+	// on behalf of their users reject.
+	AllowUnusable bool `json:"-"`
 }
 
 type CreateTenantRequest struct {
-	Name                    string `json:"name"`
-	Domain                  string `json:"port"`
-	Timezone                string `json:"timezone"`
-	SubscriptionType        int    `json:"subscription_type"`
+	Name                    string `json:"name"                      validate:"required,max=255"`
+	Domain                  string `json:"domain"                    validate:"required,hostname"`
+	Timezone                string `json:"timezone"                  validate:"required,timezone"`
+	SubscriptionType        int    `json:"subscription_type"         validate:"required,enum=1|2|3"`
 	SelfRegistrationEnabled bool   `json:"self_registration_enabled"`
 }
 
 type UpdateTenantRequest struct {
-	ID                      uuid.UUID `json:"id"`
-	Name                    string    `json:"name"`
-	SubscriptionType        int       `json:"subscription_type"`
+	ID                      uuid.UUID `json:"id"                        validate:"required"`
+	Name                    string    `json:"name"                      validate:"required,max=255"`
+	SubscriptionType        int       `json:"subscription_type"         validate:"required,enum=1|2|3"`
 	SelfRegistrationEnabled bool      `json:"self_registration_enabled"`
+	// Version is the version of the tenant that the update was based on. The update is rejected when the tenant
+	// has been changed since, and applies to any version when it is 0. It is sent in the If-Match header over
+	// HTTP, where it is required, and in the if-match metadata over gRPC, where it is optional.
+	Version int `json:"-"`
+	// Mask lists the fields to change, out of UpdatableFields. The other fields of the request are ignored.
+	Mask domain.FieldMask `json:"-"`
+}
+
+// ChangeTenantStatusRequest moves a tenant to another status, for the reason that is recorded along with it.
+type ChangeTenantStatusRequest struct {
+	ID     uuid.UUID `json:"id"     validate:"required"`
+	Reason string    `json:"reason" validate:"required,max=500"`
+}
+
+// DeleteTenantRequest deletes a tenant on behalf of DeletedBy, the user or operator who the client says asked for
+// it. The service does not authenticate its callers, so that DeletedBy is a note for the operators that cannot be
+// trusted as an audit record.
+type DeleteTenantRequest struct {
+	ID        uuid.UUID `json:"id"         validate:"required"`
+	DeletedBy string    `json:"deleted_by" validate:"required,max=255"`
+	Reason    string    `json:"reason"     validate:"max=500"`
 }
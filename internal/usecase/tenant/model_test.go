@@ -0,0 +1,52 @@
+package tenant
+
+import (
+	"testing"
+
+	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/fixture"
+)
+
+func TestParseSorts(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		orderBy string
+		sorts   []domain.Sort
+		want    []domain.Sort
+		wantErr bool
+	}{
+		{
+			name:    "WithPublicName",
+			orderBy: "domain desc",
+			want: []domain.Sort{
+				{Field: "port", Order: domain.Desc},
+				{Field: "created_at", Order: domain.Asc},
+				{Field: "id", Order: domain.Asc},
+			},
+		},
+		{
+			name:  "WithSortsOfRequest",
+			sorts: []domain.Sort{{Field: "name", Order: domain.Asc}, {Field: "id", Order: domain.Desc}},
+			want: []domain.Sort{
+				{Field: "name", Order: domain.Asc},
+				{Field: "id", Order: domain.Desc},
+				{Field: "created_at", Order: domain.Asc},
+			},
+		},
+		{name: "WithColumnName", orderBy: "port", wantErr: true},
+		{name: "WithInternalColumn", orderBy: "deleted_at", wantErr: true},
+		{name: "WithVersion", sorts: []domain.Sort{{Field: "version"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseSorts(tt.orderBy, tt.sorts...)
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, err)
+		})
+	}
+}
@@ -1,14 +1,30 @@
 package tenant
 
 import (
+	"context"
+
+	"github.com/vnworkday/account/internal/domain/repository"
 	"github.com/vnworkday/common/pkg/ioc"
 	"go.uber.org/fx"
 )
 
 func Register() fx.Option {
-	return fx.Provide(
-		ioc.RegisterWithName(NewService, "tenant_service"),
-		ioc.RegisterWithName(NewValidator, "tenant_validator"),
-		ioc.RegisterWithName(NewPort, "tenant_port"),
+	return fx.Options(
+		fx.Provide(
+			ioc.RegisterWithName(NewService, "tenant_service"),
+			ioc.RegisterWithName(NewValidator, "tenant_validator"),
+			ioc.RegisterWithName(NewPort, "tenant_port"),
+			ioc.RegisterWithGroup(repository.NewTenantDataPurger, "tenant_purgers", new(DataPurger)),
+			fx.Annotate(
+				NewPurgeJob,
+				fx.OnStart(func(ctx context.Context, job *PurgeJob) error {
+					return job.Start(ctx)
+				}),
+				fx.OnStop(func(ctx context.Context, job *PurgeJob) error {
+					return job.Stop(ctx)
+				}),
+			),
+		),
+		fx.Invoke(func(*PurgeJob) {}),
 	)
 }
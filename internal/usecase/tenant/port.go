@@ -2,9 +2,11 @@ package tenant
 
 import (
 	"context"
+	"database/sql"
 
 	"github.com/vnworkday/account/internal/common/domain"
 	"github.com/vnworkday/account/internal/common/port"
+	"github.com/vnworkday/account/internal/common/repo"
 
 	"github.com/vnworkday/account/internal/domain/entity"
 
@@ -18,14 +20,24 @@ type Port struct {
 	DoGetTenant    endpoint.Endpoint
 	DoCreateTenant endpoint.Endpoint
 	DoUpdateTenant endpoint.Endpoint
+
+	DoSuspendTenant    endpoint.Endpoint
+	DoReactivateTenant endpoint.Endpoint
+	DoDeactivateTenant endpoint.Endpoint
+	DoDeleteTenant     endpoint.Endpoint
+	DoUndeleteTenant   endpoint.Endpoint
 }
 
 type PortParams struct {
 	fx.In
-	Logger  *zap.Logger
-	Service Service `name:"tenant_service"`
+	Logger    *zap.Logger
+	Service   Service   `name:"tenant_service"`
+	Validator Validator `name:"tenant_validator"`
+	UoW       repo.UnitOfWork
 }
 
+// NewPort validates the writes in the serializable transaction of the use case, so that concurrent requests
+// cannot both pass the existence checks; the loser is retried and then fails them.
 func NewPort(params PortParams) Port {
 	return Port{
 		DoListTenants: port.MakeEndpoint[domain.ListRequest, domain.ListResponse[entity.Tenant]](
@@ -38,12 +50,41 @@ func NewPort(params PortParams) Port {
 		),
 		DoCreateTenant: port.MakeEndpoint[CreateTenantRequest, entity.Tenant](
 			params.Service.CreateTenant,
+			port.ValidationMiddleware(params.Validator.ValidateCreateTenant),
+			port.TransactionMiddleware(params.UoW, repo.WithIsolation(sql.LevelSerializable)),
 			port.LoggingMiddleware(params.Logger.With(zap.String("method", "CreateTenant"))),
 		),
 		DoUpdateTenant: port.MakeEndpoint[UpdateTenantRequest, entity.Tenant](
 			params.Service.UpdateTenant,
+			port.ValidationMiddleware(params.Validator.ValidateUpdateTenant),
+			port.TransactionMiddleware(params.UoW, repo.WithIsolation(sql.LevelSerializable)),
 			port.LoggingMiddleware(params.Logger.With(zap.String("method", "UpdateTenant"))),
 		),
+		DoSuspendTenant: port.MakeEndpoint[ChangeTenantStatusRequest, entity.Tenant](
+			params.Service.SuspendTenant,
+			port.ValidationMiddleware(params.Validator.ValidateChangeTenantStatus),
+			port.LoggingMiddleware(params.Logger.With(zap.String("method", "SuspendTenant"))),
+		),
+		DoReactivateTenant: port.MakeEndpoint[ChangeTenantStatusRequest, entity.Tenant](
+			params.Service.ReactivateTenant,
+			port.ValidationMiddleware(params.Validator.ValidateChangeTenantStatus),
+			port.LoggingMiddleware(params.Logger.With(zap.String("method", "ReactivateTenant"))),
+		),
+		DoDeactivateTenant: port.MakeEndpoint[ChangeTenantStatusRequest, entity.Tenant](
+			params.Service.DeactivateTenant,
+			port.ValidationMiddleware(params.Validator.ValidateChangeTenantStatus),
+			port.LoggingMiddleware(params.Logger.With(zap.String("method", "DeactivateTenant"))),
+		),
+		DoDeleteTenant: port.MakeEndpoint[DeleteTenantRequest, entity.Tenant](
+			params.Service.DeleteTenant,
+			port.ValidationMiddleware(params.Validator.ValidateDeleteTenant),
+			port.LoggingMiddleware(params.Logger.With(zap.String("method", "DeleteTenant"))),
+		),
+		DoUndeleteTenant: port.MakeEndpoint[ChangeTenantStatusRequest, entity.Tenant](
+			params.Service.UndeleteTenant,
+			port.ValidationMiddleware(params.Validator.ValidateChangeTenantStatus),
+			port.LoggingMiddleware(params.Logger.With(zap.String("method", "UndeleteTenant"))),
+		),
 	}
 }
 
@@ -68,9 +109,46 @@ func (t Port) CreateTenant(
 	return port.Delegate[CreateTenantRequest, entity.Tenant](ctx, request, t.DoCreateTenant)
 }
 
+// UpdateTenant rejects the update with a Conflict error when the tenant has been changed since the version of the
+// request, and overwrites the tenant whatever its version when the request has none.
 func (t Port) UpdateTenant(
 	ctx context.Context,
 	request *UpdateTenantRequest,
 ) (*entity.Tenant, error) {
 	return port.Delegate[UpdateTenantRequest, entity.Tenant](ctx, request, t.DoUpdateTenant)
 }
+
+func (t Port) SuspendTenant(
+	ctx context.Context,
+	request *ChangeTenantStatusRequest,
+) (*entity.Tenant, error) {
+	return port.Delegate[ChangeTenantStatusRequest, entity.Tenant](ctx, request, t.DoSuspendTenant)
+}
+
+func (t Port) ReactivateTenant(
+	ctx context.Context,
+	request *ChangeTenantStatusRequest,
+) (*entity.Tenant, error) {
+	return port.Delegate[ChangeTenantStatusRequest, entity.Tenant](ctx, request, t.DoReactivateTenant)
+}
+
+func (t Port) DeactivateTenant(
+	ctx context.Context,
+	request *ChangeTenantStatusRequest,
+) (*entity.Tenant, error) {
+	return port.Delegate[ChangeTenantStatusRequest, entity.Tenant](ctx, request, t.DoDeactivateTenant)
+}
+
+func (t Port) DeleteTenant(
+	ctx context.Context,
+	request *DeleteTenantRequest,
+) (*entity.Tenant, error) {
+	return port.Delegate[DeleteTenantRequest, entity.Tenant](ctx, request, t.DoDeleteTenant)
+}
+
+func (t Port) UndeleteTenant(
+	ctx context.Context,
+	request *ChangeTenantStatusRequest,
+) (*entity.Tenant, error) {
+	return port.Delegate[ChangeTenantStatusRequest, entity.Tenant](ctx, request, t.DoUndeleteTenant)
+}
@@ -0,0 +1,85 @@
+package tenant
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/vnworkday/account/internal/conf"
+	"go.uber.org/fx"
+	"go.uber.org/zap"
+)
+
+// PurgeJob purges the tenants whose deletion grace period has expired, on every tick of its interval.
+type PurgeJob struct {
+	service  Service
+	logger   *zap.Logger
+	interval time.Duration
+	cancel   context.CancelFunc
+	done     sync.WaitGroup
+}
+
+type PurgeJobParams struct {
+	fx.In
+	Logger  *zap.Logger
+	Config  *conf.Conf
+	Service Service `name:"tenant_service"`
+}
+
+func NewPurgeJob(params PurgeJobParams) *PurgeJob {
+	return &PurgeJob{
+		service:  params.Service,
+		logger:   params.Logger.With(zap.String("job", "tenant_purge")),
+		interval: params.Config.TenantPurgeInterval,
+	}
+}
+
+// Start runs the job in the background, so that the first purge does not delay the start of the service.
+func (j *PurgeJob) Start(_ context.Context) error {
+	loopCtx, cancel := context.WithCancel(context.Background())
+	j.cancel = cancel
+
+	j.done.Add(1)
+
+	go func() {
+		defer j.done.Done()
+
+		ticker := time.NewTicker(j.interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-loopCtx.Done():
+				return
+			case <-ticker.C:
+				j.run(loopCtx)
+			}
+		}
+	}()
+
+	return nil
+}
+
+// Stop ends the job, after the purge in progress if any.
+func (j *PurgeJob) Stop(_ context.Context) error {
+	if j.cancel != nil {
+		j.cancel()
+	}
+
+	j.done.Wait()
+
+	return nil
+}
+
+func (j *PurgeJob) run(ctx context.Context) {
+	purged, err := j.service.PurgeDeletedTenants(ctx)
+	if err != nil {
+		j.logger.Error("cannot purge deleted tenants", zap.Error(err))
+
+		return
+	}
+
+	if purged > 0 {
+		j.logger.Info("purged deleted tenants", zap.Int("count", purged))
+	}
+}
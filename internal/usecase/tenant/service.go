@@ -2,9 +2,15 @@ package tenant
 
 import (
 	"context"
+	"database/sql"
 	"time"
 
 	"github.com/vnworkday/account/internal/common/domain"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/paging"
+	"github.com/vnworkday/account/internal/common/repo"
+	"github.com/vnworkday/account/internal/common/util"
+	"github.com/vnworkday/account/internal/conf"
 
 	"github.com/vnworkday/account/internal/domain/entity"
 	"github.com/vnworkday/account/internal/domain/repository"
@@ -17,35 +23,92 @@ import (
 	"go.uber.org/zap"
 )
 
+// purgeBatchSize bounds the number of tenants that a run of PurgeDeletedTenants removes.
+const purgeBatchSize = 100
+
 type Service interface {
 	ListTenants(ctx context.Context, request *domain.ListRequest) (*domain.ListResponse[entity.Tenant], error)
 	GetTenant(ctx context.Context, request *GetTenantRequest) (*entity.Tenant, error)
 	CreateTenant(ctx context.Context, request *CreateTenantRequest) (*entity.Tenant, error)
 	UpdateTenant(ctx context.Context, request *UpdateTenantRequest) (*entity.Tenant, error)
+	// SuspendTenant locks an active tenant, whose lookups are then rejected until it is reactivated.
+	SuspendTenant(ctx context.Context, request *ChangeTenantStatusRequest) (*entity.Tenant, error)
+	// ReactivateTenant activates a pending tenant, or brings back a suspended or deactivated one.
+	ReactivateTenant(ctx context.Context, request *ChangeTenantStatusRequest) (*entity.Tenant, error)
+	// DeactivateTenant closes a tenant on behalf of its admin.
+	DeactivateTenant(ctx context.Context, request *ChangeTenantStatusRequest) (*entity.Tenant, error)
+	// DeleteTenant hides the tenant, which can be undeleted until its grace period expires and it is purged.
+	DeleteTenant(ctx context.Context, request *DeleteTenantRequest) (*entity.Tenant, error)
+	// UndeleteTenant restores a deleted tenant, in the status it had before, within its grace period.
+	UndeleteTenant(ctx context.Context, request *ChangeTenantStatusRequest) (*entity.Tenant, error)
+	// PurgeDeletedTenants removes a batch of the tenants whose grace period has expired, together with their data,
+	// in a transaction each, and returns how many were purged. A tenant that fails is logged and left for the
+	// next run.
+	PurgeDeletedTenants(ctx context.Context) (int, error)
+}
+
+// DataPurger removes the data that a part of the service keeps for a tenant, in the transaction that purges the
+// tenant. Implementations are provided in the tenant_purgers group.
+type DataPurger interface {
+	PurgeTenantData(ctx context.Context, tenantID uuid.UUID) error
 }
 
 type ServiceParams struct {
 	fx.In
 	Logger *zap.Logger
 	Store  repository.TenantRepo `name:"tenant_store"`
+	UoW    repo.UnitOfWork
+	Codec  *paging.Codec
+	Policy *paging.Policy
+	Config *conf.Conf
+
+	Purgers []DataPurger `group:"tenant_purgers"`
 }
 
 func NewService(params ServiceParams) Service {
 	return &service{
 		logger: params.Logger,
 		store:  params.Store,
+		uow:    params.UoW,
+		codec:  params.Codec,
+		policy: params.Policy,
+
+		gracePeriod: params.Config.TenantDeletionGracePeriod,
+		purgers:     params.Purgers,
 	}
 }
 
 type service struct {
 	logger *zap.Logger
 	store  repository.TenantRepo
+	uow    repo.UnitOfWork
+	codec  *paging.Codec
+	policy *paging.Policy
+
+	gracePeriod time.Duration
+	purgers     []DataPurger
 }
 
 func (s service) ListTenants(
 	ctx context.Context,
 	request *domain.ListRequest,
 ) (*domain.ListResponse[entity.Tenant], error) {
+	pagination, err := s.policy.Apply(request.Pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	request = &domain.ListRequest{
+		Pagination: pagination,
+		Filters:    request.Filters,
+		Sorts:      request.Sorts,
+	}
+
+	prepared, err := s.codec.Prepare(request)
+	if err != nil {
+		return nil, err
+	}
+
 	eg, egCtx := syncs.NewCtxErrGroup(ctx)
 
 	var tenants []*entity.Tenant
@@ -53,26 +116,35 @@ func (s service) ListTenants(
 
 	eg.Go(func() error {
 		var err error
-		tenants, err = s.store.FindAll(egCtx, request)
+		tenants, err = s.store.FindAll(egCtx, prepared)
 
 		return err
 	})
 
 	eg.Go(func() error {
 		var err error
-		count, err = s.store.CountAll(egCtx, request)
+		count, err = s.store.CountAll(egCtx, prepared)
 
 		return err
 	})
 
-	err := eg.Wait()
+	err = eg.Wait()
+	if err != nil {
+		return nil, err
+	}
+
+	tenants, page, err := paging.Keyset(s.codec, prepared, tenants)
 	if err != nil {
 		return nil, err
 	}
 
+	page.Total = int(count)
+	page.TotalPages = paging.TotalPages(page.Total, request.Pagination.Limit)
+
 	return &domain.ListResponse[entity.Tenant]{
 		Items: tenants,
 		Count: int(count),
+		Page:  page,
 	}, nil
 }
 
@@ -80,11 +152,26 @@ func (s service) GetTenant(
 	ctx context.Context,
 	request *GetTenantRequest,
 ) (*entity.Tenant, error) {
-	tenant, err := s.store.FindByID(ctx, request.ID)
+	var tenant *entity.Tenant
+	var err error
+
+	if request.PublicID != "" {
+		tenant, err = s.store.FindByPublicID(ctx, request.PublicID)
+	} else {
+		tenant, err = s.store.FindByID(ctx, request.ID)
+	}
+
 	if err != nil {
 		return nil, err
 	}
 
+	if !request.AllowUnusable && !tenant.Status.Usable() {
+		return nil, errs.New(errs.FailedPrecondition, "tenant is %s", tenant.Status).
+			WithReason("TENANT_NOT_ACTIVE").
+			WithResource("tenant", tenant.ID.String()).
+			WithMetadata("status", tenant.Status.String())
+	}
+
 	return tenant, nil
 }
 
@@ -92,50 +179,262 @@ func (s service) CreateTenant(
 	ctx context.Context,
 	request *CreateTenantRequest,
 ) (*entity.Tenant, error) {
-	now := time.Now()
-	tenantID := uuid.New()
-	tenant := &entity.Tenant{
-		ID:                      tenantID,
-		Name:                    request.Name,
-		Status:                  1,
-		Domain:                  request.Domain,
-		Timezone:                request.Timezone,
-		ProductionType:          1,
-		SubscriptionType:        1,
-		SelfRegistrationEnabled: request.SelfRegistrationEnabled,
-		CreatedAt:               now,
-		UpdatedAt:               now,
-	}
-
-	err := s.store.Save(ctx, tenant)
+	var created *entity.Tenant
+
+	// The request has been validated by the port, in the transaction that this one joins.
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		publicID, err := util.NewPublicID(entity.TenantPublicIDPrefix)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+		tenant := &entity.Tenant{
+			ID:                      uuid.New(),
+			PublicID:                publicID,
+			Name:                    request.Name,
+			Status:                  entity.TenantStatusPending,
+			Domain:                  request.Domain,
+			Timezone:                request.Timezone,
+			ProductionType:          entity.TenantProductionTypeEnterprise,
+			SubscriptionType:        request.SubscriptionType,
+			SelfRegistrationEnabled: request.SelfRegistrationEnabled,
+			CreatedAt:               now,
+			UpdatedAt:               now,
+		}
+
+		if err = s.store.Save(ctx, tenant); err != nil {
+			return err
+		}
+
+		created = tenant
+
+		return nil
+	}, repo.WithIsolation(sql.LevelSerializable))
 	if err != nil {
-		return tenant, err
+		return nil, err
 	}
 
-	return s.store.FindByID(ctx, tenantID)
+	return created, nil
 }
 
 func (s service) UpdateTenant(
 	ctx context.Context,
 	request *UpdateTenantRequest,
 ) (*entity.Tenant, error) {
-	now := time.Now()
-	tenantID := request.ID
+	var updated *entity.Tenant
 
-	tenant, err := s.store.FindByID(ctx, tenantID)
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		tenant, err := s.store.FindByID(ctx, request.ID)
+		if err != nil {
+			return err
+		}
+
+		columns := applyUpdate(tenant, request)
+		// The update only applies to the version that the client read, so that concurrent edits are detected
+		// instead of overwriting each other. Clients that send no version overwrite the tenant whatever its
+		// version.
+		tenant.Version = request.Version
+
+		if err = s.store.Update(ctx, tenant, columns...); err != nil {
+			return err
+		}
+
+		updated = tenant
+
+		return nil
+	}, repo.WithIsolation(sql.LevelSerializable))
 	if err != nil {
 		return nil, err
 	}
 
-	tenant.Name = request.Name
-	tenant.SubscriptionType = request.SubscriptionType
-	tenant.SelfRegistrationEnabled = request.SelfRegistrationEnabled
-	tenant.UpdatedAt = now
+	return updated, nil
+}
+
+func (s service) SuspendTenant(
+	ctx context.Context,
+	request *ChangeTenantStatusRequest,
+) (*entity.Tenant, error) {
+	return s.changeStatus(ctx, request, entity.TenantStatusSuspended)
+}
+
+func (s service) ReactivateTenant(
+	ctx context.Context,
+	request *ChangeTenantStatusRequest,
+) (*entity.Tenant, error) {
+	return s.changeStatus(ctx, request, entity.TenantStatusActive)
+}
+
+func (s service) DeactivateTenant(
+	ctx context.Context,
+	request *ChangeTenantStatusRequest,
+) (*entity.Tenant, error) {
+	return s.changeStatus(ctx, request, entity.TenantStatusDeactivated)
+}
+
+func (s service) DeleteTenant(
+	ctx context.Context,
+	request *DeleteTenantRequest,
+) (*entity.Tenant, error) {
+	return s.transition(ctx, s.store, request.ID, request.Reason,
+		func(tenant *entity.Tenant, now time.Time) (entity.TenantStatus, error) {
+			tenant.DeletedAt = &now
+			tenant.DeletedBy = request.DeletedBy
+			tenant.StatusBeforeDeletion = tenant.Status
+
+			return entity.TenantStatusPendingDeletion, nil
+		}, "deleted_at", "deleted_by", "status_before_deletion")
+}
+
+func (s service) UndeleteTenant(
+	ctx context.Context,
+	request *ChangeTenantStatusRequest,
+) (*entity.Tenant, error) {
+	return s.transition(ctx, s.store.Unscoped(), request.ID, request.Reason,
+		func(tenant *entity.Tenant, now time.Time) (entity.TenantStatus, error) {
+			if tenant.DeletedAt == nil {
+				return 0, errs.New(errs.FailedPrecondition, "tenant is not deleted").
+					WithReason("TENANT_NOT_DELETED").
+					WithResource("tenant", tenant.ID.String())
+			}
+
+			if now.Sub(*tenant.DeletedAt) > s.gracePeriod {
+				return 0, errs.New(errs.FailedPrecondition, "tenant was deleted more than %s ago", s.gracePeriod).
+					WithReason("DELETION_GRACE_PERIOD_EXPIRED").
+					WithResource("tenant", tenant.ID.String())
+			}
+
+			// Tenants deleted before their previous status was recorded come back active.
+			restored := tenant.StatusBeforeDeletion
+			if restored == 0 {
+				restored = entity.TenantStatusActive
+			}
+
+			tenant.DeletedAt = nil
+			tenant.DeletedBy = ""
+			tenant.StatusBeforeDeletion = 0
+
+			return restored, nil
+		}, "deleted_at", "deleted_by", "status_before_deletion")
+}
+
+func (s service) PurgeDeletedTenants(ctx context.Context) (int, error) {
+	tenants, err := s.store.FindAllDeletedBefore(ctx, time.Now().Add(-s.gracePeriod), purgeBatchSize)
+	if err != nil {
+		return 0, err
+	}
+
+	purged := 0
+
+	for _, tenant := range tenants {
+		err = s.uow.Do(ctx, func(ctx context.Context) error {
+			for _, purger := range s.purgers {
+				if err := purger.PurgeTenantData(ctx, tenant.ID); err != nil {
+					return err
+				}
+			}
+
+			return s.store.Purge(ctx, tenant.ID)
+		})
+		if err != nil {
+			s.logger.Error("cannot purge tenant", zap.Stringer("id", tenant.ID), zap.Error(err))
+
+			continue
+		}
+
+		purged++
+	}
+
+	return purged, nil
+}
+
+func (s service) changeStatus(
+	ctx context.Context,
+	request *ChangeTenantStatusRequest,
+	status entity.TenantStatus,
+) (*entity.Tenant, error) {
+	return s.transition(ctx, s.store, request.ID, request.Reason,
+		func(*entity.Tenant, time.Time) (entity.TenantStatus, error) {
+			return status, nil
+		})
+}
+
+// transition moves the tenant of the store to the status that apply returns, if its transition table allows it,
+// and records the reason. Apply may change the tenant further, or refuse the transition, before the columns of its
+// status and the given ones are written.
+func (s service) transition(
+	ctx context.Context,
+	store repository.TenantRepo,
+	id uuid.UUID,
+	reason string,
+	apply func(tenant *entity.Tenant, now time.Time) (entity.TenantStatus, error),
+	columns ...string,
+) (*entity.Tenant, error) {
+	var changed *entity.Tenant
+
+	err := s.uow.Do(ctx, func(ctx context.Context) error {
+		tenant, err := store.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		now := time.Now()
+
+		status, err := apply(tenant, now)
+		if err != nil {
+			return err
+		}
+
+		if !tenant.Status.CanTransitionTo(status) {
+			return errs.New(errs.FailedPrecondition, "tenant cannot move from %s to %s", tenant.Status, status).
+				WithReason("INVALID_STATUS_TRANSITION").
+				WithResource("tenant", tenant.ID.String()).
+				WithMetadata("from", tenant.Status.String()).
+				WithMetadata("to", status.String())
+		}
+
+		tenant.Status = status
+		tenant.StatusReason = reason
+		tenant.StatusChangedAt = &now
+		tenant.UpdatedAt = now
+
+		written := append([]string{"status", "status_reason", "status_changed_at", "updated_at"}, columns...)
+
+		if err = store.Update(ctx, tenant, written...); err != nil {
+			return err
+		}
+
+		changed = tenant
 
-	err = s.store.Save(ctx, tenant)
+		return nil
+	}, repo.WithIsolation(sql.LevelSerializable))
 	if err != nil {
-		return tenant, err
+		return nil, err
 	}
 
-	return tenant, nil
+	return changed, nil
+}
+
+// applyUpdate copies the masked fields of the request onto the tenant and returns the columns to write.
+func applyUpdate(tenant *entity.Tenant, request *UpdateTenantRequest) []string {
+	columns := make([]string, 0, len(UpdatableFields)+1)
+
+	if request.Mask.Has("name") {
+		tenant.Name = request.Name
+		columns = append(columns, "name")
+	}
+
+	if request.Mask.Has("subscription_type") {
+		tenant.SubscriptionType = request.SubscriptionType
+		columns = append(columns, "subscription_type")
+	}
+
+	if request.Mask.Has("self_registration_enabled") {
+		tenant.SelfRegistrationEnabled = request.SelfRegistrationEnabled
+		columns = append(columns, "self_registration_enabled")
+	}
+
+	tenant.UpdatedAt = time.Now()
+
+	return append(columns, "updated_at")
 }
@@ -0,0 +1,375 @@
+package tenant
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	tenantv1 "buf.build/gen/go/ntduycs/vnworkday/protocolbuffers/go/account/tenant/v1"
+	"github.com/google/uuid"
+	"github.com/vnworkday/account/internal/common/errs"
+	"github.com/vnworkday/account/internal/common/fixture"
+	"github.com/vnworkday/account/internal/common/repo"
+	"github.com/vnworkday/account/internal/conf"
+	"github.com/vnworkday/account/internal/domain/entity"
+	"github.com/vnworkday/account/internal/domain/repository"
+	"go.uber.org/zap"
+)
+
+// memoryStore keeps tenants in memory and hides the deleted ones unless it is unscoped. The methods that the tests
+// do not need are left to the nil TenantRepo, and panic when called.
+type memoryStore struct {
+	repository.TenantRepo
+
+	mu       *sync.Mutex
+	tenants  map[uuid.UUID]entity.Tenant
+	unscoped bool
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{mu: new(sync.Mutex), tenants: make(map[uuid.UUID]entity.Tenant)}
+}
+
+func (s *memoryStore) Unscoped() repository.TenantRepo {
+	return &memoryStore{mu: s.mu, tenants: s.tenants, unscoped: true}
+}
+
+func (s *memoryStore) Save(_ context.Context, tenant *entity.Tenant) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tenant.Version++
+	s.tenants[tenant.ID] = *tenant
+
+	return nil
+}
+
+func (s *memoryStore) Update(ctx context.Context, tenant *entity.Tenant, _ ...string) error {
+	return s.Save(ctx, tenant)
+}
+
+func (s *memoryStore) FindByID(_ context.Context, id uuid.UUID) (*entity.Tenant, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tenant, ok := s.tenants[id]
+	if !ok || (tenant.DeletedAt != nil && !s.unscoped) {
+		return nil, errs.NewNotFound("tenant", id.String())
+	}
+
+	return &tenant, nil
+}
+
+func (s *memoryStore) FindByPublicID(_ context.Context, publicID string) (*entity.Tenant, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tenant := range s.tenants {
+		if tenant.PublicID == publicID && (tenant.DeletedAt == nil || s.unscoped) {
+			return &tenant, nil
+		}
+	}
+
+	return nil, errs.NewNotFound("tenant", publicID)
+}
+
+func (s *memoryStore) FindAllDeletedBefore(_ context.Context, before time.Time, _ int) ([]*entity.Tenant, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deleted := make([]*entity.Tenant, 0)
+
+	for _, tenant := range s.tenants {
+		if tenant.DeletedAt != nil && tenant.DeletedAt.Before(before) {
+			deleted = append(deleted, &tenant)
+		}
+	}
+
+	return deleted, nil
+}
+
+func (s *memoryStore) Purge(_ context.Context, id uuid.UUID) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.tenants, id)
+
+	return nil
+}
+
+// change edits a stored tenant behind the back of the service.
+func (s *memoryStore) change(id uuid.UUID, fn func(tenant *entity.Tenant)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	tenant := s.tenants[id]
+	fn(&tenant)
+	s.tenants[id] = tenant
+}
+
+// recordingPurger records the tenants whose data it purges, and fails for the tenant given to failFor.
+type recordingPurger struct {
+	failFor uuid.UUID
+	purged  []uuid.UUID
+}
+
+func (p *recordingPurger) PurgeTenantData(_ context.Context, tenantID uuid.UUID) error {
+	if tenantID == p.failFor {
+		return errs.New(errs.FailedPrecondition, "tenant data is locked")
+	}
+
+	p.purged = append(p.purged, tenantID)
+
+	return nil
+}
+
+// inlineUnitOfWork runs the work without a transaction.
+type inlineUnitOfWork struct{}
+
+func (inlineUnitOfWork) Do(ctx context.Context, fn func(ctx context.Context) error, _ ...repo.TxOption) error {
+	return fn(ctx)
+}
+
+func newTestService(store repository.TenantRepo, purgers ...DataPurger) Service {
+	return NewService(ServiceParams{
+		Logger:  zap.NewNop(),
+		Store:   store,
+		UoW:     inlineUnitOfWork{},
+		Config:  &conf.Conf{TenantDeletionGracePeriod: time.Hour},
+		Purgers: purgers,
+	})
+}
+
+func createTestTenant(t *testing.T, service Service, name string) *entity.Tenant {
+	t.Helper()
+
+	request, err := ToCreateRequest(context.Background(), &tenantv1.CreateTenantRequest{
+		Name:             name,
+		Domain:           name + ".example.com",
+		Timezone:         "Asia/Ho_Chi_Minh",
+		SubscriptionType: tenantv1.TenantSubscriptionType_TENANT_SUBSCRIPTION_TYPE_STANDARD,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	created, err := service.CreateTenant(context.Background(), request)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return created
+}
+
+func TestService_CreateTenant(t *testing.T) {
+	t.Parallel()
+
+	type result struct {
+		Status           entity.TenantStatus
+		ProductionType   int
+		SubscriptionType int
+	}
+
+	created := createTestTenant(t, newTestService(newMemoryStore()), "acme")
+
+	got := result{
+		Status:           created.Status,
+		ProductionType:   created.ProductionType,
+		SubscriptionType: created.SubscriptionType,
+	}
+	want := result{
+		Status:           entity.TenantStatusPending,
+		ProductionType:   entity.TenantProductionTypeEnterprise,
+		SubscriptionType: int(tenantv1.TenantSubscriptionType_TENANT_SUBSCRIPTION_TYPE_STANDARD),
+	}
+
+	fixture.ExpectationsWereMet(t, want, got, false, nil)
+}
+
+func TestService_GetCreatedTenant(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		status   entity.TenantStatus
+		publicID bool
+		want     tenantv1.TenantStatus
+		wantErr  bool
+	}{
+		{name: "Pending", want: tenantv1.TenantStatus_TENANT_STATUS_PROVISIONING},
+		{name: "PendingByPublicID", publicID: true, want: tenantv1.TenantStatus_TENANT_STATUS_PROVISIONING},
+		{name: "Active", status: entity.TenantStatusActive, want: tenantv1.TenantStatus_TENANT_STATUS_ACTIVE},
+		{name: "Suspended", status: entity.TenantStatusSuspended, wantErr: true},
+		{name: "Deactivated", status: entity.TenantStatusDeactivated, wantErr: true},
+		{name: "PendingDeletion", status: entity.TenantStatusPendingDeletion, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			store := newMemoryStore()
+			service := newTestService(store)
+
+			created := createTestTenant(t, service, "acme")
+
+			if tt.status != 0 {
+				store.change(created.ID, func(tenant *entity.Tenant) {
+					tenant.Status = tt.status
+				})
+			}
+
+			id := created.ID.String()
+			if tt.publicID {
+				id = created.PublicID
+			}
+
+			var got tenantv1.TenantStatus
+
+			getRequest, err := ToGetRequest(ctx, &tenantv1.GetTenantRequest{Id: id})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tenant, gotErr := service.GetTenant(ctx, getRequest)
+			if gotErr == nil {
+				response, err := ToGetResponse(ctx, tenant)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got = response.GetTenant().GetStatus()
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestService_UndeleteTenant(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		status  entity.TenantStatus
+		change  func(tenant *entity.Tenant)
+		want    entity.TenantStatus
+		wantErr bool
+	}{
+		{name: "Pending", status: entity.TenantStatusPending, want: entity.TenantStatusPending},
+		{name: "Active", status: entity.TenantStatusActive, want: entity.TenantStatusActive},
+		{name: "Suspended", status: entity.TenantStatusSuspended, want: entity.TenantStatusSuspended},
+		{name: "Deactivated", status: entity.TenantStatusDeactivated, want: entity.TenantStatusDeactivated},
+		{
+			name:   "WithoutPreviousStatus",
+			status: entity.TenantStatusSuspended,
+			change: func(tenant *entity.Tenant) {
+				tenant.StatusBeforeDeletion = 0
+			},
+			want: entity.TenantStatusActive,
+		},
+		{
+			name:   "AfterGracePeriod",
+			status: entity.TenantStatusActive,
+			change: func(tenant *entity.Tenant) {
+				deletedAt := tenant.DeletedAt.Add(-2 * time.Hour)
+				tenant.DeletedAt = &deletedAt
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			ctx := context.Background()
+			store := newMemoryStore()
+			service := newTestService(store)
+			created := createTestTenant(t, service, "acme")
+
+			store.change(created.ID, func(tenant *entity.Tenant) {
+				tenant.Status = tt.status
+			})
+
+			_, err := service.DeleteTenant(ctx, &DeleteTenantRequest{ID: created.ID, Reason: "closed"})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tt.change != nil {
+				store.change(created.ID, tt.change)
+			}
+
+			var got entity.TenantStatus
+
+			undeleted, gotErr := service.UndeleteTenant(ctx, &ChangeTenantStatusRequest{ID: created.ID, Reason: "oops"})
+			if gotErr == nil {
+				got = undeleted.Status
+			}
+
+			fixture.ExpectationsWereMet(t, tt.want, got, tt.wantErr, gotErr)
+		})
+	}
+}
+
+func TestService_UndeleteTenantNotDeleted(t *testing.T) {
+	t.Parallel()
+
+	service := newTestService(newMemoryStore())
+	created := createTestTenant(t, service, "acme")
+
+	_, err := service.UndeleteTenant(context.Background(), &ChangeTenantStatusRequest{ID: created.ID, Reason: "oops"})
+
+	fixture.ExpectationsWereMet(t, "TENANT_NOT_DELETED", errs.As(err).ErrorReason(), false, nil)
+}
+
+func TestService_PurgeDeletedTenants(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	store := newMemoryStore()
+	purger := new(recordingPurger)
+	service := newTestService(store, purger)
+
+	expired := createTestTenant(t, service, "expired")
+	locked := createTestTenant(t, service, "locked")
+	recent := createTestTenant(t, service, "recent")
+	kept := createTestTenant(t, service, "kept")
+
+	purger.failFor = locked.ID
+
+	for _, tenant := range []*entity.Tenant{expired, locked, recent} {
+		if _, err := service.DeleteTenant(ctx, &DeleteTenantRequest{ID: tenant.ID, Reason: "closed"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tenant := range []*entity.Tenant{expired, locked} {
+		store.change(tenant.ID, func(tenant *entity.Tenant) {
+			deletedAt := tenant.DeletedAt.Add(-2 * time.Hour)
+			tenant.DeletedAt = &deletedAt
+		})
+	}
+
+	type result struct {
+		Purged    int
+		PurgedIDs []uuid.UUID
+		Remaining []bool
+	}
+
+	purged, err := service.PurgeDeletedTenants(ctx)
+
+	got := result{Purged: purged, PurgedIDs: purger.purged}
+
+	for _, tenant := range []*entity.Tenant{expired, locked, recent, kept} {
+		_, findErr := store.Unscoped().FindByID(ctx, tenant.ID)
+		got.Remaining = append(got.Remaining, findErr == nil)
+	}
+
+	want := result{Purged: 1, PurgedIDs: []uuid.UUID{expired.ID}, Remaining: []bool{false, true, true, true}}
+
+	fixture.ExpectationsWereMet(t, want, got, false, err)
+}
@@ -3,6 +3,7 @@ package tenant
 import (
 	"context"
 
+	"github.com/vnworkday/account/internal/common/errs"
 	"github.com/vnworkday/account/internal/common/util"
 
 	validator2 "github.com/vnworkday/account/internal/common/validator"
@@ -15,6 +16,8 @@ import (
 type Validator interface {
 	ValidateCreateTenant(ctx context.Context, request *CreateTenantRequest) error
 	ValidateUpdateTenant(ctx context.Context, request *UpdateTenantRequest) error
+	ValidateChangeTenantStatus(ctx context.Context, request *ChangeTenantStatusRequest) error
+	ValidateDeleteTenant(ctx context.Context, request *DeleteTenantRequest) error
 }
 
 type ValidatorParams struct {
@@ -34,32 +37,49 @@ func NewValidator(params ValidatorParams) Validator {
 
 func (v validator) ValidateCreateTenant(ctx context.Context, request *CreateTenantRequest) error {
 	validations := []validator2.ValidationFunc{
+		validator2.Rules(),
 		v.validateNameNotExists,
 		v.validateDomainNotExists,
 	}
 
-	return validator2.Validate(ctx, request, validations...)
+	return validator2.ValidateAll(ctx, request, validations...)
 }
 
+// ValidateUpdateTenant only validates the fields that the update changes.
 func (v validator) ValidateUpdateTenant(ctx context.Context, request *UpdateTenantRequest) error {
 	validations := []validator2.ValidationFunc{
-		v.validateNameNotExists,
+		validator2.Rules(request.Mask...),
+	}
+
+	if request.Mask.Has("name") {
+		validations = append(validations, v.validateNameNotExists)
 	}
 
-	return validator2.Validate(ctx, request, validations...)
+	return validator2.ValidateAll(ctx, request, validations...)
+}
+
+func (v validator) ValidateChangeTenantStatus(_ context.Context, request *ChangeTenantStatusRequest) error {
+	return validator2.Struct(request)
+}
+
+func (v validator) ValidateDeleteTenant(_ context.Context, request *DeleteTenantRequest) error {
+	return validator2.Struct(request)
 }
 
 // validateNameNotExists checks if the tenant name already exists.
 func (v validator) validateNameNotExists(ctx context.Context, request any) error {
 	var exist bool
+	var name string
 	var err error
 
 	switch util.Type(request) {
 	case "*CreateTenantRequest":
 		req := util.SafeCast[*CreateTenantRequest](request)
+		name = req.Name
 		exist, err = v.repo.ExistByName(ctx, req.Name)
 	case "*UpdateTenantRequest":
 		req := util.SafeCast[*UpdateTenantRequest](request)
+		name = req.Name
 		exist, err = v.repo.ExistByNameAndIDNot(ctx, req.Name, req.ID)
 	default:
 		return errors.New("validator: unrecognized request")
@@ -70,7 +90,10 @@ func (v validator) validateNameNotExists(ctx context.Context, request any) error
 	}
 
 	if exist {
-		return errors.New("validator: tenant name already exists")
+		return errs.NewAlreadyExists("tenant", "").
+			WithReason("TENANT_NAME_EXISTS").
+			WithViolations(errs.NewViolation("name", "ALREADY_TAKEN", nil)).
+			WithMetadata("name", name)
 	}
 
 	return nil
@@ -89,7 +112,10 @@ func (v validator) validateDomainNotExists(ctx context.Context, request any) err
 	}
 
 	if exist {
-		return errors.New("validator: tenant domain already exists")
+		return errs.NewAlreadyExists("tenant", "").
+			WithReason("TENANT_DOMAIN_EXISTS").
+			WithViolations(errs.NewViolation("domain", "ALREADY_TAKEN", nil)).
+			WithMetadata("domain", req.Domain)
 	}
 
 	return nil
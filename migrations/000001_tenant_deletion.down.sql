@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS tenant_deleted_at_idx;
+
+ALTER TABLE tenant
+    DROP COLUMN IF EXISTS status_before_deletion,
+    DROP COLUMN IF EXISTS deleted_by,
+    DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,9 @@
+-- Deleted tenants keep their row until they are purged, along with who the client said deleted them, which is not
+-- verified, and the status they get back when they are undeleted. Their name and domain stay taken until then, see
+-- the unique indexes of 000005.
+ALTER TABLE tenant
+    ADD COLUMN IF NOT EXISTS deleted_at             TIMESTAMPTZ,
+    ADD COLUMN IF NOT EXISTS deleted_by             VARCHAR(255) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS status_before_deletion INTEGER      NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS tenant_deleted_at_idx ON tenant (deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tenant_public_id_key;
+
+ALTER TABLE tenant DROP COLUMN IF EXISTS public_id;
@@ -0,0 +1,18 @@
+-- Public ids are "tn_" followed by 16 symbols of Crockford's base32, like those of util.NewPublicID. Existing
+-- tenants get one from the first byte of a fresh random UUID per symbol. The subquery refers to the row, so that
+-- it is evaluated for each tenant instead of once.
+ALTER TABLE tenant ADD COLUMN IF NOT EXISTS public_id VARCHAR(32);
+
+UPDATE tenant
+SET public_id = 'tn_' || (
+    SELECT string_agg(
+        substr('0123456789ABCDEFGHJKMNPQRSTVWXYZ', get_byte(uuid_send(gen_random_uuid()), 0) % 32 + 1, 1), ''
+    )
+    FROM generate_series(1, 16)
+    WHERE tenant.id IS NOT NULL
+)
+WHERE public_id IS NULL OR public_id = '';
+
+ALTER TABLE tenant ALTER COLUMN public_id SET NOT NULL;
+
+CREATE UNIQUE INDEX IF NOT EXISTS tenant_public_id_key ON tenant (public_id);
@@ -0,0 +1 @@
+ALTER TABLE tenant DROP COLUMN IF EXISTS version;
@@ -0,0 +1,3 @@
+-- The version is bumped by every update, which only applies when it still matches the version read by the client.
+-- Existing tenants start at 1, like the new ones.
+ALTER TABLE tenant ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
@@ -0,0 +1,3 @@
+ALTER TABLE tenant
+    DROP COLUMN IF EXISTS status_changed_at,
+    DROP COLUMN IF EXISTS status_reason;
@@ -0,0 +1,5 @@
+-- The reason and time of the last status change of a tenant, such as why it was suspended. Existing tenants have
+-- no recorded change, whose reasons are at most 500 characters, as validated by the API.
+ALTER TABLE tenant
+    ADD COLUMN IF NOT EXISTS status_reason     VARCHAR(500) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS status_changed_at TIMESTAMPTZ;
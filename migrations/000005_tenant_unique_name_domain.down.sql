@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS tenant_port_key;
+
+DROP INDEX IF EXISTS tenant_name_key;
@@ -0,0 +1,6 @@
+-- Names and domains are unique among all tenants, deleted or not, so that two tenants created at once cannot both
+-- pass the checks of the validator. The domain is stored in the port column. Duplicates must be resolved by hand
+-- before this migration can run.
+CREATE UNIQUE INDEX IF NOT EXISTS tenant_name_key ON tenant (name);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tenant_port_key ON tenant (port);